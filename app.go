@@ -1,29 +1,167 @@
 package life
 
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
 type App struct {
-	Game   *game
-	Preset *presets
-	Theme  *themes
+	Game      *game
+	Preset    *presets
+	Theme     *themes
+	Clipboard *clipboard
+	Speed     *speed
+	Keys      *keymap
+	Goal      *goal
+}
+
+// Option configures NewApp.
+type Option func(*options)
+
+type options struct {
+	image  imageOptions
+	rewind int
+	period time.Duration
+	dirs   []string
+	theme  string
+	preset string
+	keys   map[string][]string
+}
+
+// WithImage sets how an image pattern becomes cells: pixels darker than
+// threshold are alive, or lighter ones when inverted, after scaling.
+func WithImage(threshold uint8, scale float64, invert bool) Option {
+	return func(o *options) {
+		o.image = imageOptions{
+			threshold: threshold,
+			scale:     scale,
+			invert:    invert,
+		}
+	}
+}
+
+// WithRewind keeps the last n generations to step back.
+func WithRewind(n int) Option {
+	return func(o *options) {
+		o.rewind = n
+	}
+}
+
+// WithPeriod sets the starting refresh period.
+func WithPeriod(d time.Duration) Option {
+	return func(o *options) {
+		o.period = d
+	}
+}
+
+// WithPresetDirs adds the presets in the directories, besides the ones in
+// the XDG data directory.
+func WithPresetDirs(dirs ...string) Option {
+	return func(o *options) {
+		o.dirs = append(o.dirs, dirs...)
+	}
+}
+
+// WithTheme starts with the theme by name.
+func WithTheme(name string) Option {
+	return func(o *options) {
+		o.theme = name
+	}
+}
+
+// WithPreset starts with the preset by name.
+func WithPreset(name string) Option {
+	return func(o *options) {
+		o.preset = name
+	}
 }
 
-func NewApp(w, h int, file string) (*App, error) {
+// WithKeys binds the actions by name to the keys in place of their own.
+func WithKeys(keys map[string][]string) Option {
+	return func(o *options) {
+		o.keys = keys
+	}
+}
+
+func NewApp(w, h int, file string, opts ...Option) (*App, error) {
+	o := options{
+		image:  defaultImage,
+		rewind: rewindLimit,
+		period: 100 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	g := newGame(w, h)
+	g.r = newRewind(o.rewind)
 	if file != "" {
-		s, err := parseFile(file)
+		s, err := parseFile(file, o.image)
 		if err != nil {
 			return nil, err
 		}
 		g.SetState(0, 0, s)
+		g.h = history{}
 	}
 
-	p, err := newPresets()
+	dirs := o.dirs
+	if d := presetDir(); d != "" {
+		if _, err := os.Stat(d); err == nil {
+			dirs = append([]string{d}, dirs...)
+		}
+	}
+	p, err := newPresets(dirs...)
 	if err != nil {
 		return nil, err
 	}
 
+	t := newThemes()
+	if d := themeDir(); d != "" {
+		if _, err := os.Stat(d); err == nil {
+			t.loadDir(d)
+		}
+	}
+
+	if o.theme != "" && !t.Set(o.theme) {
+		return nil, fmt.Errorf("theme %q is unknown", o.theme)
+	}
+	if o.preset != "" && !p.Set(o.preset) {
+		return nil, fmt.Errorf("preset %q is unknown", o.preset)
+	}
+
+	k := newKeymap()
+	actions := make([]string, 0, len(o.keys))
+	for a := range o.keys {
+		actions = append(actions, a)
+	}
+	// Sorted, so that the same key in two actions always ends up in one.
+	sort.Strings(actions)
+	for _, a := range actions {
+		if err := k.bind(Action(a), o.keys[a]); err != nil {
+			return nil, err
+		}
+	}
+
 	return &App{
-		Game:   g,
-		Preset: p,
-		Theme:  newThemes(),
+		Game:      g,
+		Preset:    p,
+		Theme:     t,
+		Clipboard: &clipboard{},
+		Speed:     newSpeed(o.period),
+		Keys:      k,
+		Goal:      &goal{},
 	}, nil
 }
+
+// LoadErrors tells how many user presets and themes failed to load and why
+// the first, empty if none did.
+func (a *App) LoadErrors() string {
+	errs := append([]error{}, a.Preset.Errors()...)
+	errs = append(errs, a.Theme.Errors()...)
+	if len(errs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d files failed to load, %v", len(errs), errs[0])
+}
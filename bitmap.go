@@ -0,0 +1,57 @@
+package life
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+)
+
+type imageOptions struct {
+	threshold uint8
+	scale     float64
+	invert    bool
+}
+
+var defaultImage = imageOptions{
+	threshold: 128,
+	scale:     1,
+}
+
+// bitmap turns dark pixels of the image into alive cells.
+func bitmap(r io.Reader, o imageOptions) ([][]int, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("parse image: %w", err)
+	}
+	if o.scale <= 0 {
+		return nil, fmt.Errorf("parse image: scale %v must be positive", o.scale)
+	}
+	b := img.Bounds()
+	w := int(math.Round(float64(b.Dx()) * o.scale))
+	h := int(math.Round(float64(b.Dy()) * o.scale))
+	state := newState(max(w, 1), max(h, 1))
+	for y := range state {
+		for x := range state[y] {
+			px := b.Min.X + int((float64(x)+0.5)/o.scale)
+			py := b.Min.Y + int((float64(y)+0.5)/o.scale)
+			state[y][x] = pixel(img.At(min(px, b.Max.X-1), min(py, b.Max.Y-1)), o)
+		}
+	}
+	return state, nil
+}
+
+func pixel(c color.Color, o imageOptions) int {
+	if _, _, _, a := c.RGBA(); a < math.MaxUint16/2 {
+		return 0
+	}
+	dark := color.GrayModel.Convert(c).(color.Gray).Y < o.threshold
+	if dark != o.invert {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,105 @@
+package life
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_bitmap(t *testing.T) {
+	encode := func(rows ...string) io.Reader {
+		img := image.NewGray(image.Rect(0, 0, len(rows[0]), len(rows)))
+		for y, row := range rows {
+			for x, r := range row {
+				c := color.Gray{Y: 255}
+				if r == '#' {
+					c = color.Gray{Y: 0}
+				}
+				img.SetGray(x, y, c)
+			}
+		}
+		var b bytes.Buffer
+		png.Encode(&b, img)
+		return &b
+	}
+	type args struct {
+		rows []string
+		o    imageOptions
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    [][]int
+		wantErr bool
+	}{
+		{
+			name: "glider",
+			args: args{
+				rows: []string{".#.", "..#", "###"},
+				o:    defaultImage,
+			},
+			want: [][]int{
+				{0, 1, 0},
+				{0, 0, 1},
+				{1, 1, 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invert",
+			args: args{
+				rows: []string{"#.", ".#"},
+				o: imageOptions{
+					threshold: 128,
+					scale:     1,
+					invert:    true,
+				},
+			},
+			want: [][]int{
+				{0, 1},
+				{1, 0},
+			},
+			wantErr: false,
+		},
+		{
+			name: "half",
+			args: args{
+				rows: []string{"##..", "##..", "....", "...."},
+				o: imageOptions{
+					threshold: 128,
+					scale:     0.5,
+				},
+			},
+			want: [][]int{
+				{1, 0},
+				{0, 0},
+			},
+			wantErr: false,
+		},
+		{
+			name: "zero scale",
+			args: args{
+				rows: []string{"#"},
+				o:    imageOptions{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bitmap(encode(tt.args.rows...), tt.args.o)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bitmap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bitmap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package life
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Categories a preset is classified into by simulating it.
+const (
+	CategoryStillLife   = "still life"
+	CategoryOscillator  = "oscillator"
+	CategorySpaceship   = "spaceship"
+	CategoryGun         = "gun"
+	CategoryPuffer      = "puffer"
+	CategoryMethuselah  = "methuselah"
+	CategoryUnknown     = "other"
+	classifyGenerations = 300
+	// classifyPopulation stops the simulation of a growing pattern.
+	classifyPopulation = 2000
+	// methuselahSize is the largest population a methuselah starts from.
+	methuselahSize = 12
+	// methuselahGenerations before a pattern settles make it a methuselah.
+	methuselahGenerations = 50
+	// debrisPeriod repeats the still lifes and the common oscillators a
+	// puffer leaves behind.
+	debrisPeriod = 6
+)
+
+// plane of alive cells, unbounded.
+type plane map[point]struct{}
+
+func newPlane(s [][]int) plane {
+	c := plane{}
+	for y := range s {
+		for x, v := range s[y] {
+			if v > 0 {
+				c[point{x: x, y: y}] = struct{}{}
+			}
+		}
+	}
+	return c
+}
+
+func (c plane) next() plane {
+	count := map[point]int{}
+	for p := range c {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if dx != 0 || dy != 0 {
+					count[point{x: p.x + dx, y: p.y + dy}]++
+				}
+			}
+		}
+	}
+	n := plane{}
+	for p, k := range count {
+		if _, alive := c[p]; k == 3 || k == 2 && alive {
+			n[p] = struct{}{}
+		}
+	}
+	return n
+}
+
+// shape of the cells apart from their position, and the position.
+func (c plane) shape() (string, point) {
+	ps := make([]point, 0, len(c))
+	for p := range c {
+		ps = append(ps, p)
+	}
+	if len(ps) == 0 {
+		return "", point{}
+	}
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i].y < ps[j].y || ps[i].y == ps[j].y && ps[i].x < ps[j].x
+	})
+	o := ps[0]
+	for _, p := range ps {
+		o.x = min(o.x, p.x)
+	}
+	var b strings.Builder
+	for _, p := range ps {
+		fmt.Fprintf(&b, "%d,%d;", p.x-o.x, p.y-o.y)
+	}
+	return b.String(), o
+}
+
+// within the rectangle between corners x0 y0 and x1 y1.
+func (c plane) within(x0, y0, x1, y1 int) plane {
+	n := plane{}
+	for p := range c {
+		if p.x >= x0 && p.x <= x1 && p.y >= y0 && p.y <= y1 {
+			n[p] = struct{}{}
+		}
+	}
+	return n
+}
+
+// classify the pattern by how it evolves: a pattern that settles quickly is
+// classified by what it settles into, one that keeps growing is a gun when
+// its origin stays busy and a puffer when it leaves debris behind.
+func classify(s [][]int) string {
+	c := newPlane(s)
+	size := len(c)
+	if size == 0 {
+		return CategoryUnknown
+	}
+	w, h := Size(s)
+	type seen struct {
+		gen int
+		at  point
+	}
+	shapes := map[string]seen{}
+	origin := []string{}
+	for gen := 0; gen <= classifyGenerations; gen++ {
+		if len(c) == 0 {
+			return CategoryUnknown
+		}
+		if len(c) > classifyPopulation {
+			break
+		}
+		shape, at := c.shape()
+		if first, ok := shapes[shape]; ok {
+			switch {
+			case first.gen >= methuselahGenerations && size <= methuselahSize:
+				return CategoryMethuselah
+			case first.at != at:
+				return CategorySpaceship
+			case gen-first.gen == 1:
+				return CategoryStillLife
+			default:
+				return CategoryOscillator
+			}
+		}
+		shapes[shape] = seen{gen: gen, at: at}
+		o, _ := c.within(-2, -2, w+1, h+1).shape()
+		origin = append(origin, o)
+		c = c.next()
+	}
+	switch {
+	case size <= methuselahSize:
+		return CategoryUnknown
+	case len(c) > 2*size:
+		if n := len(origin); n > debrisPeriod && origin[n-1] == origin[n-1-debrisPeriod] {
+			return CategoryPuffer
+		}
+		return CategoryGun
+	default:
+		return CategoryUnknown
+	}
+}
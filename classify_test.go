@@ -0,0 +1,69 @@
+package life
+
+import (
+	"path"
+	"testing"
+)
+
+func Test_classify(t *testing.T) {
+	embed := func(name string) [][]int {
+		s, err := parseFileEmbed(embedFS, path.Join(embedDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	tests := []struct {
+		name string
+		s    [][]int
+		want string
+	}{
+		{
+			name: "block",
+			s:    [][]int{{1, 1}, {1, 1}},
+			want: CategoryStillLife,
+		},
+		{
+			name: "blinker",
+			s:    [][]int{{1, 1, 1}},
+			want: CategoryOscillator,
+		},
+		{
+			name: "glider",
+			s:    [][]int{{0, 1, 0}, {0, 0, 1}, {1, 1, 1}},
+			want: CategorySpaceship,
+		},
+		{
+			name: "pi-heptomino",
+			s:    [][]int{{1, 1, 1}, {1, 0, 1}, {1, 0, 1}},
+			want: CategoryMethuselah,
+		},
+		{
+			name: "r-pentomino does not settle in time",
+			s:    [][]int{{0, 1, 1}, {1, 1, 0}, {0, 1, 0}},
+			want: CategoryUnknown,
+		},
+		{
+			name: "glider gun",
+			s:    embed("glider-gun.life"),
+			want: CategoryGun,
+		},
+		{
+			name: "blinker puffer",
+			s:    embed("blinkerpuffer.life"),
+			want: CategoryPuffer,
+		},
+		{
+			name: "dies",
+			s:    [][]int{{1}},
+			want: CategoryUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classify(tt.s); got != tt.want {
+				t.Errorf("classify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
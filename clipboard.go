@@ -0,0 +1,111 @@
+package life
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rleLine is the longest line of the encoded pattern.
+const rleLine = 70
+
+// encodeRLE writes the alive cells of s in run length encoded format.
+func encodeRLE(s [][]int) string {
+	w, h := Size(s)
+	var b strings.Builder
+	fmt.Fprintf(&b, "x = %d, y = %d, rule = B3/S23\n", w, h)
+
+	var line strings.Builder
+	put := func(count int, tag byte) {
+		token := string(tag)
+		if count > 1 {
+			token = fmt.Sprint(count, token)
+		}
+		if line.Len()+len(token) > rleLine {
+			b.WriteString(line.String())
+			b.WriteByte('\n')
+			line.Reset()
+		}
+		line.WriteString(token)
+	}
+	ends, first := 0, true
+	for _, row := range s {
+		runs := []run{}
+		for _, cycle := range row {
+			v := 0
+			if cycle > 0 {
+				v = 1
+			}
+			if l := len(runs) - 1; l >= 0 && runs[l].cycle == v {
+				runs[l].count++
+				continue
+			}
+			runs = append(runs, run{cycle: v, count: 1})
+		}
+		// Trailing dead cells are implied.
+		if l := len(runs) - 1; l >= 0 && runs[l].cycle == 0 {
+			runs = runs[:l]
+		}
+		if len(runs) == 0 {
+			ends++
+			continue
+		}
+		// A dead cell starts the leading blank rows, rle skips ends of
+		// empty rows.
+		if ends > 0 && first {
+			put(1, 'b')
+		}
+		if ends > 0 {
+			put(ends, '$')
+		}
+		first = false
+		for _, r := range runs {
+			tag := byte('b')
+			if r.cycle == 1 {
+				tag = 'o'
+			}
+			put(r.count, tag)
+		}
+		ends = 1
+	}
+	put(1, '!')
+	b.WriteString(line.String())
+	b.WriteByte('\n')
+	return b.String()
+}
+
+type clipboard struct {
+	state [][]int
+}
+
+// Set the clipboard contents.
+func (c *clipboard) Set(s [][]int) {
+	c.state = s
+}
+
+// State of the clipboard.
+func (c *clipboard) State() [][]int {
+	return c.state
+}
+
+// Empty reports whether nothing was copied.
+func (c *clipboard) Empty() bool {
+	return len(c.state) == 0
+}
+
+// Text of the clipboard in run length encoded format.
+func (c *clipboard) Text() string {
+	return encodeRLE(c.state)
+}
+
+// SetText replaces the clipboard with a run length encoded pattern.
+func (c *clipboard) SetText(text string) error {
+	_, _, s, err := rle(strings.NewReader(text))
+	if err != nil {
+		return err
+	}
+	if _, _, _, _, ok := state(s).bounds(func(cycle int) bool { return cycle > 0 }); !ok {
+		return fmt.Errorf("parse rle: no alive cells")
+	}
+	c.state = s
+	return nil
+}
@@ -0,0 +1,94 @@
+package life
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_encodeRLE(t *testing.T) {
+	tests := []struct {
+		name string
+		s    [][]int
+		want string
+	}{
+		{
+			name: "glider",
+			s: [][]int{
+				{0, 1, 0},
+				{0, 0, 1},
+				{1, 1, 1},
+			},
+			want: "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n",
+		},
+		{
+			name: "gaps",
+			s: [][]int{
+				{0, 0},
+				{1, 0},
+				{0, 0},
+				{0, 0},
+				{0, 1},
+			},
+			want: "x = 2, y = 5, rule = B3/S23\nb$o3$bo!\n",
+		},
+		{
+			name: "cycles",
+			s: [][]int{
+				{3, -1, 7},
+			},
+			want: "x = 3, y = 1, rule = B3/S23\nobo!\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeRLE(tt.s); got != tt.want {
+				t.Errorf("encodeRLE() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_clipboard_SetText(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       [][]int
+		want    [][]int
+		wantErr bool
+	}{
+		{
+			name: "round trip",
+			s: [][]int{
+				{0, 0, 0},
+				{0, 1, 1},
+				{0, 0, 0},
+				{1, 0, 1},
+			},
+			want: [][]int{
+				{0},
+				{0, 1, 1},
+				{0},
+				{1, 0, 1},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			s:       [][]int{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &clipboard{}
+			err := c.SetText(encodeRLE(tt.s))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("clipboard.SetText() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(c.State(), tt.want) {
+				t.Errorf("clipboard.State() = %v, want %v", c.State(), tt.want)
+			}
+		})
+	}
+}
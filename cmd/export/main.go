@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/amettod/life"
+)
+
+func main() {
+	w := flag.Int("w", 40, "board width")
+	h := flag.Int("h", 23, "board height")
+	f := flag.String("f", "", "pattern filename, \"-\" reads standard input, random board if empty")
+	o := flag.String("o", "life.gif", "output filename, .gif animation, numbered .png sequence or .svg snapshot")
+	n := flag.Int("n", 100, "number of generations to run")
+	cell := flag.Int("cell", 8, "cell size in pixels")
+	grid := flag.Bool("grid", false, "draw grid lines between the cells")
+	d := flag.Duration("d", 100*time.Millisecond, "animation frame duration")
+	t := flag.String("t", "", "theme name")
+	trail := flag.Bool("trail", false, "svg snapshot draws the decaying dead cells")
+	crop := flag.Bool("crop", false, "svg snapshot crops to the drawn cells")
+	flag.Parse()
+
+	a, err := life.NewApp(*w, *h, *f)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *f == "" {
+		a.Game.Random()
+	}
+	if *t != "" && !a.Theme.Set(*t) {
+		log.Fatalf("unknown theme %q", *t)
+	}
+
+	e := life.Export{
+		Generations: *n,
+		Cell:        *cell,
+		Grid:        *grid,
+		Delay:       *d,
+	}
+	switch path.Ext(*o) {
+	case ".gif":
+		err = writeGIF(a, *o, e)
+	case ".png":
+		err = writePNG(a, *o, e)
+	case ".svg":
+		err = writeSVG(a, *o, e, life.SVG{
+			Cell:  *cell,
+			Trail: *trail,
+			Crop:  *crop,
+		})
+	default:
+		err = fmt.Errorf("output %s is unsupported", *o)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeGIF(a *life.App, name string, e life.Export) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := a.WriteGIF(f, e); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writePNG frames into files numbered by generation, "gen.png" becomes
+// "gen0000.png", "gen0001.png" and so on unless name has its own verb.
+func writePNG(a *life.App, name string, e life.Export) error {
+	if !strings.Contains(name, "%") {
+		name = strings.TrimSuffix(name, ".png") + "%04d.png"
+	}
+	return a.Frames(e, func(generation int, img *image.Paletted) error {
+		f, err := os.Create(fmt.Sprintf(name, generation))
+		if err != nil {
+			return err
+		}
+		if err := png.Encode(f, img); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	})
+}
+
+// writeSVG snapshot of the last generation.
+func writeSVG(a *life.App, name string, e life.Export, s life.SVG) error {
+	for i := 0; i < e.Generations; i++ {
+		a.Game.Step()
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := a.WriteSVG(f, s); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
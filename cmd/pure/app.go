@@ -3,39 +3,89 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/amettod/life"
 	"github.com/amettod/life/term"
 )
 
 const (
-	unitCell = "  "
-	unitHide = "@"
+	unitCell   = "  "
+	unitCursor = "[]"
+	unitHide   = "@"
 )
 
+// escapes are the key names of the arrow key sequences.
+var escapes = map[string]string{
+	"\x1b[A": "Up",
+	"\x1b[B": "Down",
+	"\x1b[C": "Right",
+	"\x1b[D": "Left",
+}
+
 type app struct {
 	*life.App
 
-	term term.Term
+	term   term.Term
+	colors term.Mode
+	input  io.Reader
+	rec    io.Closer
+
+	info []string
+	msg  string
 
-	period time.Duration
-	info   []string
+	cursor           bool
+	cursorX, cursorY int
 }
 
-func newApp(w, h int, file string, d time.Duration) (*app, error) {
-	a, err := life.NewApp(w, h, file)
+func newApp(w, h int, file string, rec string, colors term.Mode, opts ...life.Option) (*app, error) {
+	a, err := life.NewApp(w, h, file, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	// The pattern has consumed standard input, so keys come from the terminal.
+	var input io.Reader = os.Stdin
+	if file == "-" {
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			return nil, err
+		}
+		input = tty
+	}
+
+	var out io.Writer = os.Stdout
+	var closer io.Closer
+	if rec != "" {
+		f, err := os.Create(rec)
+		if err != nil {
+			return nil, err
+		}
+		cast, err := term.NewCast(f, w*len(unitCell), h+1)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		out = io.MultiWriter(out, cast)
+		closer = f
+	}
+
+	if colors == term.Auto {
+		colors = term.Detect()
+	}
+
 	return &app{
 		App:    a,
-		term:   term.New(os.Stdout),
-		period: d,
+		term:   term.New(out, colors),
+		colors: colors,
+		input:  input,
+		rec:    closer,
 		info:   make([]string, h),
+		msg:    a.LoadErrors(),
 	}, nil
 }
 
@@ -63,10 +113,22 @@ func (a *app) show() {
 					)
 					continue
 				}
+				if a.cursor && x/len(unitCell) == a.cursorX && y == a.cursorY {
+					a.term.Write(
+						a.Theme.Color(cycle),
+						a.Theme.Foreground(),
+						string(unitCursor[i]),
+					)
+					continue
+				}
+				unit := string(unitCell[i])
+				if a.colors == term.Mono {
+					unit = string(a.Theme.Shade(cycle))
+				}
 				a.term.Write(
 					a.Theme.Color(cycle),
 					nil,
-					string(unitCell[i]),
+					unit,
 				)
 			}
 		}
@@ -76,79 +138,183 @@ func (a *app) show() {
 	a.term.Print()
 }
 
-func (a *app) waitEvent(e chan<- event) {
-	scan := bufio.NewScanner(os.Stdin)
+func (a *app) waitEvent(e chan<- event, n chan<- eventArg) {
+	scan := bufio.NewScanner(a.input)
+	cursor := false
 	for scan.Scan() {
 		line := scan.Text()
-		switch {
-		case strings.Contains(line, "q"):
-			e <- eventQuit
-		case strings.Contains(line, " "):
-			e <- eventPause
-		case strings.Contains(line, "t"):
-			e <- eventTheme
-		case strings.Contains(line, "r"):
-			e <- eventRandom
-		case strings.Contains(line, "c"):
-			e <- eventClear
-		case strings.Contains(line, "s"):
-			e <- eventStep
-		case strings.Contains(line, "p"):
-			e <- eventSwitchPreset
-		case strings.Contains(line, "i"):
-			e <- eventInsertPreset
-		case strings.Contains(line, "h"):
-			e <- eventInfo
+		var (
+			key string
+			arg int
+		)
+		// Only step-n and goto take a count, other lines read as keys.
+		if _, err := fmt.Sscanf(line, "%s %d", &key, &arg); err == nil {
+			if act, ok := a.Keys.Action(key); ok && (act == life.ActionStepN || act == life.ActionGoto) {
+				n <- eventArg{e: actions[act], n: arg}
+				continue
+			}
+		}
+		keys := keyNames(line)
+		if cursor && a.cursorEvent(e, keys) {
+			continue
+		}
+		for _, key := range keys {
+			if act, ok := a.Keys.Action(key); ok {
+				if act == life.ActionCursor {
+					cursor = !cursor
+				}
+				if ev, ok := actions[act]; ok && act != life.ActionStepN && act != life.ActionGoto {
+					e <- ev
+				}
+				break
+			}
 		}
 	}
 }
 
-func (a *app) doEvent(e <-chan event) {
-	ticker := time.NewTicker(a.period * time.Millisecond)
+// keyNames of the keys typed in the line, an empty one is Enter.
+func keyNames(line string) []string {
+	if line == "" {
+		return []string{"Enter"}
+	}
+	var keys []string
+	for line != "" {
+		if len(line) >= 3 {
+			if k, ok := escapes[line[:3]]; ok {
+				keys = append(keys, k)
+				line = line[3:]
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(line)
+		keys = append(keys, life.KeyName(r))
+		line = line[size:]
+	}
+	return keys
+}
+
+// cursorEvent sends an event per cursor key of the line, reports whether
+// there were any.
+func (a *app) cursorEvent(e chan<- event, keys []string) bool {
+	sent := false
+	for _, key := range keys {
+		// Leaving cursor mode is left to the keys out of it.
+		act, ok := a.Keys.Cursor(key)
+		if !ok || act == life.ActionCursor {
+			continue
+		}
+		e <- actions[act]
+		sent = true
+	}
+	return sent
+}
+
+func (a *app) moveCursor(dx, dy int) {
+	a.cursorX = min(max(a.cursorX+dx, 0), a.Game.Width()-1)
+	a.cursorY = min(max(a.cursorY+dy, 0), a.Game.Height()-1)
+}
+
+func (a *app) doEvent(e <-chan event, n <-chan eventArg) {
+	ticker := time.NewTicker(a.Speed.Period())
 	stop := true
 	info := true
-	cycle := 0
 	for {
 		select {
 		case ev := <-e:
+			a.msg = ""
 			switch ev {
 			case eventQuit:
+				if a.rec != nil {
+					a.rec.Close()
+				}
 				os.Exit(0)
 			case eventPause:
 				stop = !stop
+				a.Goal.Cancel()
 			case eventTheme:
 				a.Theme.Next()
 			case eventRandom:
 				a.Game.Random()
-				cycle = 0
 			case eventClear:
 				a.Game.Clear()
-				cycle = 0
 			case eventStep:
 				a.Game.Step()
-				cycle++
 			case eventSwitchPreset:
 				a.Preset.Next()
 			case eventInsertPreset:
+				if a.cursor {
+					a.Game.SetState(a.cursorX, a.cursorY, a.Preset.State())
+					break
+				}
+				a.Game.Begin()
 				a.Game.Clear()
 				a.Game.SetState(0, 0, a.Preset.State())
-				cycle = 0
+				a.Game.Commit()
+			case eventCursor:
+				a.cursor = !a.cursor
+			case eventUp:
+				a.moveCursor(0, -1)
+			case eventDown:
+				a.moveCursor(0, 1)
+			case eventLeft:
+				a.moveCursor(-1, 0)
+			case eventRight:
+				a.moveCursor(1, 0)
+			case eventToggle:
+				a.Game.Shift(a.cursorX, a.cursorY)
 			case eventInfo:
 				info = !info
+			case eventUndo:
+				a.Game.Undo()
+			case eventRedo:
+				a.Game.Redo()
+			case eventBack:
+				a.Game.Back()
+			case eventFaster:
+				a.Speed.Faster()
+				ticker.Reset(a.Speed.Period())
+			case eventSlower:
+				a.Speed.Slower()
+				ticker.Reset(a.Speed.Period())
 			}
+		case arg := <-n:
+			to := arg.n
+			if arg.e == eventStepN {
+				to += a.Game.Cycle()
+			}
+			a.Goal.Set(a.Game.Cycle(), to)
 		case <-ticker.C:
 			if stop && info {
 				h := a.Game.Height()
-				a.setInfo(0, 0, fmt.Sprintf("Cycle: %d", cycle))
-				a.setInfo(0, h-4, "Press <key>+RET:")
-				a.setInfo(0, h-3, fmt.Sprintf("<t>: switch theme, Current: \"%s\"", a.Theme.Name()))
-				a.setInfo(0, h-2, fmt.Sprintf("<p>: switch present, <i>: insert preset, Current: \"%s\"", a.Preset.Name()))
-				a.setInfo(0, h-1, "<SPC>: pause, <s>: next, <c>: clear, <r>: random, <h>: hide this message")
+				status := fmt.Sprintf("Cycle: %d, Speed: %s, %.1f gen/s", a.Game.Cycle(), a.Speed, a.Speed.Rate())
+				if a.cursor {
+					status += fmt.Sprintf(", Cursor: %d,%d", a.cursorX, a.cursorY)
+				}
+				a.setInfo(0, 0, status)
+				k := a.Keys
+				a.setInfo(0, h-6, "Press <key>+RET, <Enter> is RET alone:")
+				a.setInfo(0, h-5, fmt.Sprintf("%s, %s", k.Help("<%s>: %s", life.ActionFaster, life.ActionSlower), k.Help("<%s> N: %s", life.ActionStepN, life.ActionGoto)))
+				a.setInfo(0, h-4, fmt.Sprintf("%s, <%s>: move, %s",
+					k.Help("<%s>: %s", life.ActionCursor), k.Join(life.ActionUp, life.ActionDown, life.ActionLeft, life.ActionRight),
+					k.Help("<%s>: %s", life.ActionToggle)))
+				a.setInfo(0, h-3, fmt.Sprintf("%s, Current: \"%s\"", k.Help("<%s>: %s", life.ActionTheme), a.Theme.Name()))
+				a.setInfo(0, h-2, fmt.Sprintf("%s, Current: \"%s\" (%s)", k.Help("<%s>: %s", life.ActionPreset, life.ActionInsert), a.Preset.Name(), a.Preset.Category()))
+				a.setInfo(0, h-1, k.Help("<%s>: %s", life.ActionPause, life.ActionStep, life.ActionBack, life.ActionClear, life.ActionRandom, life.ActionUndo, life.ActionRedo, life.ActionInfo, life.ActionQuit))
 			}
 
-			if !stop {
-				a.Game.Step()
-				cycle++
+			switch {
+			case a.Goal.Active():
+				n, msg := a.Goal.Run(a.Game, time.Now().Add(a.Speed.Period()*3/4))
+				a.Speed.Count(n)
+				a.msg = msg
+			case !stop:
+				for i := 0; i < a.Speed.Skip(); i++ {
+					a.Game.Step()
+				}
+				a.Speed.Count(a.Speed.Skip())
+			}
+			if a.msg != "" {
+				a.setInfo(0, 1, a.msg)
 			}
 			a.show()
 		}
@@ -1,5 +1,7 @@
 package main
 
+import "github.com/amettod/life"
+
 type event uint
 
 const (
@@ -12,4 +14,49 @@ const (
 	eventSwitchPreset
 	eventInsertPreset
 	eventInfo
+	eventUndo
+	eventRedo
+	eventBack
+	eventCursor
+	eventUp
+	eventDown
+	eventLeft
+	eventRight
+	eventToggle
+	eventFaster
+	eventSlower
+	eventStepN
+	eventGoto
 )
+
+// actions are the events of the key bindings.
+var actions = map[life.Action]event{
+	life.ActionQuit:   eventQuit,
+	life.ActionPause:  eventPause,
+	life.ActionTheme:  eventTheme,
+	life.ActionRandom: eventRandom,
+	life.ActionClear:  eventClear,
+	life.ActionStep:   eventStep,
+	life.ActionPreset: eventSwitchPreset,
+	life.ActionInsert: eventInsertPreset,
+	life.ActionInfo:   eventInfo,
+	life.ActionUndo:   eventUndo,
+	life.ActionRedo:   eventRedo,
+	life.ActionBack:   eventBack,
+	life.ActionCursor: eventCursor,
+	life.ActionUp:     eventUp,
+	life.ActionDown:   eventDown,
+	life.ActionLeft:   eventLeft,
+	life.ActionRight:  eventRight,
+	life.ActionToggle: eventToggle,
+	life.ActionFaster: eventFaster,
+	life.ActionSlower: eventSlower,
+	life.ActionStepN:  eventStepN,
+	life.ActionGoto:   eventGoto,
+}
+
+// eventArg is an event with the number typed after its key.
+type eventArg struct {
+	e event
+	n int
+}
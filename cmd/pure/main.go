@@ -3,22 +3,35 @@ package main
 import (
 	"flag"
 	"log"
+	"os"
+
+	"github.com/amettod/life"
+	"github.com/amettod/life/term"
 )
 
 func main() {
-	w := flag.Int("w", 40, "board width")
-	h := flag.Int("h", 23, "board height")
-	f := flag.String("f", "", "pattern filename")
-	d := flag.Duration("d", 100, "duration of the screen refresh period in milliseconds")
-	flag.Parse()
+	fl := life.NewFlags(flag.CommandLine, 40, 23)
+	run, err := fl.Parse(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !run {
+		return
+	}
+
+	mode, err := term.ParseMode(fl.Colors)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	a, err := newApp(*w, *h, *f, *d)
+	a, err := newApp(fl.Width, fl.Height, fl.File, fl.Rec, mode, fl.Options()...)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	eventC := make(chan event)
+	argC := make(chan eventArg)
 
-	go a.waitEvent(eventC)
-	a.doEvent(eventC)
+	go a.waitEvent(eventC, argC)
+	a.doEvent(eventC, argC)
 }
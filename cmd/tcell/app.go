@@ -3,23 +3,52 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/amettod/life"
+	"github.com/amettod/life/term"
 	"github.com/gdamore/tcell/v2"
 	_ "github.com/gdamore/tcell/v2/encoding"
 )
 
+// panStep is the number of screen units an arrow key pans.
+const panStep = 4
+
 type app struct {
 	*life.App
 
 	screen tcell.Screen
+	colors term.Mode
+
+	rate int
+	rec  *recorder
 
-	period time.Duration
-	rate   int
+	// fit the board to the screen on resize.
+	fit        bool
+	view       view
+	render     render
+	overlay    overlay
+	heatMax    int
+	follow     follow
+	panX, panY int
+
+	tool             tool
+	ink              bool
+	fromX, fromY     int
+	sel              selection
+	stamp            stamp
+	mouseX, mouseY   int
+	mouseOK          bool
+	cursor           bool
+	cursorX, cursorY int
+	msg              string
+	input            string
+	browser          browser
 }
 
-func newApp(file string, d time.Duration, rate int) (*app, error) {
+func newApp(w, h int, file string, rate int, rec string, colors term.Mode, rd render, opts ...life.Option) (*app, error) {
 	s, err := tcell.NewScreen()
 	if err != nil {
 		return nil, err
@@ -29,68 +58,145 @@ func newApp(file string, d time.Duration, rate int) (*app, error) {
 		return nil, err
 	}
 
-	w, h := s.Size()
-	a, err := life.NewApp(w/rate, h, file)
+	if colors == term.Auto {
+		colors = term.ModeOf(s.Colors())
+	}
+
+	ap := &app{
+		screen: s,
+		colors: colors,
+		rate:   rate,
+		render: rd,
+		ink:    true,
+		fit:    w <= 0 || h <= 0,
+		view:   view{zoom: 1},
+	}
+	if ap.fit {
+		w, h = ap.fitSize()
+	}
+	a, err := life.NewApp(w, h, file, opts...)
 	if err != nil {
+		s.Fini()
 		return nil, err
 	}
+	ap.App = a
+	ap.stamp = a.Preset
+	ap.msg = a.LoadErrors()
+
+	if rec != "" {
+		sw, sh := s.Size()
+		if ap.rec, err = newRecorder(rec, sw, sh, colors); err != nil {
+			s.Fini()
+			return nil, err
+		}
+	}
 
-	sd := tcell.StyleDefault.
-		Background(rgbTo(a.Theme.Background())).
-		Foreground(rgbTo(a.Theme.Foreground()))
-	s.SetStyle(sd)
+	s.SetStyle(ap.style())
 	s.EnableMouse()
+	s.EnablePaste()
+	return ap, nil
+}
 
-	return &app{
-		App:    a,
-		screen: s,
-		period: d,
-		rate:   rate,
-	}, nil
+// style of the text in the theme colors.
+func (a *app) style() tcell.Style {
+	return tcell.StyleDefault.
+		Background(a.color(a.Theme.Background())).
+		Foreground(a.color(a.Theme.Foreground()))
 }
 
 func (a *app) setInfo(x, y int, msg string) {
-	sd := tcell.StyleDefault.
-		Background(rgbTo(a.Theme.Background())).
-		Foreground(rgbTo(a.Theme.Foreground()))
+	sd := a.style()
 	for i, r := range msg {
 		a.screen.SetContent(x+i, y, r, nil, sd)
 	}
 }
 
-func (a *app) waitEvent(e chan<- event, p chan<- eventPoint) {
+func (a *app) waitEvent(e chan<- event, p chan<- eventPoint, t chan<- eventText) {
+	var (
+		buttons tcell.ButtonMask
+		pasting bool
+		text    strings.Builder
+		cursor  bool
+		input   *prompt
+		query   []rune
+		browse  bool
+	)
 	for {
 		switch ev := a.screen.PollEvent().(type) {
 		case *tcell.EventResize:
 			a.screen.Sync()
 			e <- eventResize
+		case *tcell.EventPaste:
+			pasting = ev.Start()
+			if ev.End() {
+				t <- eventText{e: eventPasteText, text: text.String()}
+				text.Reset()
+			}
 		case *tcell.EventMouse:
-			switch ev.Buttons() {
-			case tcell.Button1:
-				p <- eventShift.point(ev.Position())
-			case tcell.Button2:
+			prev := buttons
+			buttons = ev.Buttons()
+			switch {
+			case buttons&tcell.Button1 != 0 && prev&tcell.Button1 == 0:
+				p <- eventPress.point(ev.Position())
+			case buttons&tcell.Button1 != 0:
+				p <- eventDrag.point(ev.Position())
+			case prev&tcell.Button1 != 0:
+				p <- eventRelease.point(ev.Position())
+			case buttons&tcell.Button2 != 0 && prev&tcell.Button2 == 0:
 				p <- eventInsert.point(ev.Position())
-			default:
-				continue
+			case buttons&tcell.Button3 != 0 && prev&tcell.Button3 == 0:
+				p <- eventPanStart.point(ev.Position())
+			case buttons&tcell.Button3 != 0:
+				p <- eventPanDrag.point(ev.Position())
+			case buttons == tcell.ButtonNone:
+				p <- eventMove.point(ev.Position())
 			}
 		case *tcell.EventKey:
+			if pasting {
+				if ev.Key() == tcell.KeyEnter {
+					text.WriteByte('\n')
+				} else if ev.Key() == tcell.KeyRune {
+					text.WriteRune(ev.Rune())
+				}
+				continue
+			}
+			if browse {
+				browse = a.browseKey(ev, e, t, &query)
+				continue
+			}
+			if input != nil {
+				if !input.key(ev, t) {
+					input = nil
+				}
+				continue
+			}
+			key := keyName(ev)
+			act, ok := a.Keys.Action(key)
+			if cursor {
+				if c, cok := a.Keys.Cursor(key); cok {
+					act, ok = c, true
+				}
+			}
+			if !ok {
+				continue
+			}
 			switch {
-			case ev.Key() == tcell.KeyEnter:
-				e <- eventStep
-			case ev.Key() == tcell.KeyEsc || ev.Key() == tcell.KeyCtrlC || ev.Rune() == 'q':
-				e <- eventQuit
-			case ev.Rune() == ' ':
-				e <- eventPause
-			case ev.Rune() == 'c':
-				e <- eventClear
-			case ev.Rune() == 'p':
-				e <- eventPreset
-			case ev.Rune() == 'r':
-				e <- eventRandom
-			case ev.Rune() == 't':
-				e <- eventTheme
-			case ev.Rune() == 'h':
-				e <- eventInfo
+			case act == life.ActionCursor:
+				cursor = !cursor
+				e <- eventCursor
+			case act == life.ActionBrowse:
+				browse, query = true, nil
+				e <- eventBrowse
+			case act == life.ActionStepN:
+				input = newPrompt(eventStepN, "Step generations: ", true)
+				input.edit(t)
+			case act == life.ActionGoto:
+				input = newPrompt(eventGoto, "Go to generation: ", true)
+				input.edit(t)
+			default:
+				if ae, ok := actions[act]; ok {
+					e <- ae
+				}
 			}
 		default:
 			continue
@@ -98,45 +204,52 @@ func (a *app) waitEvent(e chan<- event, p chan<- eventPoint) {
 	}
 }
 
-func (a *app) doEvent(e <-chan event, p <-chan eventPoint) {
-	ticker := time.NewTicker(a.period * time.Millisecond)
-	cycle := 0
+// keyName of the key as the key bindings name it.
+func keyName(ev *tcell.EventKey) string {
+	switch ev.Key() {
+	case tcell.KeyRune:
+		return life.KeyName(ev.Rune())
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		return "Backspace"
+	}
+	return strings.Replace(tcell.KeyNames[ev.Key()], "Ctrl-", "Ctrl+", 1)
+}
+
+func (a *app) doEvent(e <-chan event, p <-chan eventPoint, t <-chan eventText) {
+	ticker := time.NewTicker(a.Speed.Period())
 	stop := true
 	info := true
 	theme := a.Theme
 	for {
 		a.Theme = theme
-		a.screen.Clear()
-		for y, row := range a.Game.State() {
-			for x, cycle := range row {
-				a.screen.SetContent(x*a.rate, y, ' ', nil, tcell.StyleDefault.
-					Background(rgbTo(a.Theme.Color(cycle))))
-				a.screen.SetContent(x*a.rate+1, y, ' ', nil, tcell.StyleDefault.
-					Background(rgbTo(a.Theme.Color(cycle))))
-			}
-		}
+		a.track()
+		a.draw()
 		select {
 		case ev := <-e:
+			a.msg = ""
 			switch ev {
 			case eventRandom:
 				a.Game.Random()
-				cycle = 0
 			case eventPause:
 				stop = !stop
+				a.Goal.Cancel()
 			case eventResize:
-				w, h := a.screen.Size()
-				a.Game.Resize(w/a.rate, h)
+				if a.fit {
+					a.Game.Resize(a.fitSize())
+				}
+				a.clampView()
 			case eventStep:
-				cycle++
 				a.Game.Step()
 				a.screen.Show()
 			case eventQuit:
 				ticker.Stop()
 				a.screen.Fini()
+				if a.rec != nil {
+					a.rec.Close()
+				}
 				os.Exit(0)
 			case eventClear:
 				a.Game.Clear()
-				cycle = 0
 				a.screen.Show()
 			case eventInfo:
 				info = !info
@@ -144,32 +257,236 @@ func (a *app) doEvent(e <-chan event, p <-chan eventPoint) {
 				theme.Next()
 			case eventPreset:
 				a.Preset.Next()
+				a.stamp = a.Preset
+			case eventUndo:
+				a.Game.Undo()
+			case eventRedo:
+				a.Game.Redo()
+			case eventBack:
+				a.Game.Back()
+			case eventSelect:
+				if a.tool == toolSelect {
+					a.tool = toolToggle
+				} else {
+					a.tool = toolSelect
+				}
+				a.sel = selection{}
+			case eventCopy:
+				a.copySelection()
+			case eventCut:
+				a.cutSelection()
+			case eventPaste:
+				a.paste()
+			case eventDelete:
+				a.deleteSelection()
+			case eventRotate:
+				a.stamp.Rotate()
+			case eventFlipH:
+				a.stamp.FlipH()
+			case eventFlipV:
+				a.stamp.FlipV()
+			case eventInk:
+				a.ink = !a.ink
+			case eventCursor:
+				a.cursor = !a.cursor
+			case eventUp:
+				a.moveCursor(0, -1)
+			case eventDown:
+				a.moveCursor(0, 1)
+			case eventLeft:
+				a.moveCursor(-1, 0)
+			case eventRight:
+				a.moveCursor(1, 0)
+			case eventRender:
+				a.render = (a.render + 1) % renderCount
+				if a.fit {
+					a.Game.Resize(a.fitSize())
+				}
+				a.clampView()
+			case eventPresetNextIn:
+				a.Preset.NextInCategory()
+				a.stamp = a.Preset
+			case eventPresetPrevIn:
+				a.Preset.PrevInCategory()
+				a.stamp = a.Preset
+			case eventPresetCategory:
+				a.Preset.NextCategory()
+				a.stamp = a.Preset
+			case eventBrowse:
+				a.openBrowser()
+			case eventBrowsePrev:
+				a.browseBy(-1)
+			case eventBrowseNext:
+				a.browseBy(1)
+			case eventBrowsePageUp:
+				a.browseBy(-a.browsePage())
+			case eventBrowsePageDown:
+				a.browseBy(a.browsePage())
+			case eventBrowsePick:
+				a.pick()
+			case eventBrowseClose:
+				a.browser = browser{}
+			case eventFaster:
+				a.Speed.Faster()
+				ticker.Reset(a.Speed.Period())
+			case eventSlower:
+				a.Speed.Slower()
+				ticker.Reset(a.Speed.Period())
+			case eventOverlay:
+				a.overlay = (a.overlay + 1) % overlayCount
+			case eventFollow:
+				a.lock()
+			case eventFollowTarget:
+				a.follow.bounds = !a.follow.bounds
+			case eventZoomIn:
+				a.zoomBy(0.5)
+			case eventZoomOut:
+				a.zoomBy(2)
+			case eventPanUp:
+				a.panUnits(0, -panStep)
+			case eventPanDown:
+				a.panUnits(0, panStep)
+			case eventPanLeft:
+				a.panUnits(-panStep, 0)
+			case eventPanRight:
+				a.panUnits(panStep, 0)
+			case eventToggle:
+				a.Game.Shift(a.cursorX, a.cursorY)
+			case eventStamp:
+				// Out of cursor mode the stamp goes under the mouse.
+				x, y := a.cursorX, a.cursorY
+				if !a.cursor && a.mouseOK {
+					x, y = a.mouseX, a.mouseY
+				}
+				a.Game.SetState(x, y, a.stamp.State())
+			default:
+				if ev >= eventTool {
+					a.tool = tool(ev - eventTool)
+					a.sel = selection{}
+				}
 			}
 		case ep := <-p:
+			if a.browser.on {
+				continue
+			}
+			switch ep.e {
+			case eventPanStart:
+				a.panX, a.panY = ep.x, ep.y
+				continue
+			case eventPanDrag:
+				// Columns short of a whole unit wait for the next drag.
+				dx, dy := (a.panX-ep.x)/a.cols(), a.panY-ep.y
+				a.panUnits(dx, dy)
+				a.panX, a.panY = a.panX-dx*a.cols(), ep.y
+				continue
+			}
+			x, y := a.cell(ep.x, ep.y)
+			a.mouseX, a.mouseY, a.mouseOK = x, y, true
 			switch ep.e {
-			case eventShift:
-				a.Game.Shift(ep.x/a.rate, ep.y)
 			case eventInsert:
-				a.Game.SetState(ep.x/a.rate, ep.y, a.Preset.State())
+				a.Game.SetState(x, y, a.stamp.State())
+			case eventPress, eventDrag, eventRelease:
+				a.use(ep.e, x, y)
+			case eventMove:
+				// The button was released outside the screen.
+				a.Game.Commit()
+			}
+		case et := <-t:
+			switch et.e {
+			case eventPromptEdit:
+				a.input = et.text
+			case eventBrowseQuery:
+				a.filter(et.text)
+			case eventPasteText:
+				if err := a.Clipboard.SetText(et.text); err != nil {
+					a.msg = fmt.Sprintf("Paste: %v", err)
+				} else {
+					a.stamp = a.Clipboard
+					a.msg = "Paste: clipboard replaced, RightClick: insert it"
+				}
+			case eventStepN, eventGoto:
+				n, err := strconv.Atoi(et.text)
+				if err != nil {
+					break
+				}
+				if et.e == eventStepN {
+					n += a.Game.Cycle()
+				}
+				a.Goal.Set(a.Game.Cycle(), n)
 			}
 		case <-ticker.C:
-			if !stop {
-				cycle++
-				a.Game.Step()
+			switch {
+			case a.Game.Editing():
+				// The run waits for the drawing, so that its undo keeps to it.
+			case a.Goal.Active():
+				n, msg := a.Goal.Run(a.Game, time.Now().Add(a.Speed.Period()*3/4))
+				a.Speed.Count(n)
+				a.msg = msg
+			case !stop:
+				for i := 0; i < a.Speed.Skip(); i++ {
+					a.Game.Step()
+				}
+				a.Speed.Count(a.Speed.Skip())
 			}
-			if stop && info {
+			if a.input != "" {
 				_, h := a.screen.Size()
-				a.setInfo(0, 0, fmt.Sprintf("Cycle: %d", cycle))
-				a.setInfo(0, h-4, fmt.Sprintf("t: switch theme, Current: \"%s\"", a.Theme.Name()))
-				a.setInfo(0, h-3, fmt.Sprintf("p: switch present, Current: \"%s\"", a.Preset.Name()))
-				a.setInfo(0, h-2, "LeftClick: toggle state, RightClick: insert preset")
-				a.setInfo(0, h-1, "SPC: pause, Enter: next, c: clear, r: random, h: hide this message")
+				a.setInfo(0, h-1, a.input)
+			}
+			if a.msg != "" {
+				a.setInfo(0, 1, a.msg)
+			}
+			if a.browser.on {
+				if a.browser.preview != nil {
+					a.browser.preview.Step()
+				}
+				a.drawBrowser()
+			} else if stop && info {
+				_, h := a.screen.Size()
+				status := fmt.Sprintf("Cycle: %d, Speed: %s, %.1f gen/s, Category: %s",
+					a.Game.Cycle(), a.Speed, a.Speed.Rate(), a.Preset.Category())
+				if a.cursor {
+					status += fmt.Sprintf(", Cursor: %d,%d", a.cursorX, a.cursorY)
+				}
+				a.setInfo(0, 0, status)
+				a.setInfo(0, h-8, a.palette())
+				k := a.Keys
+				a.setInfo(0, h-11, k.Help("%s: %s", life.ActionSlower, life.ActionFaster, life.ActionStepN, life.ActionGoto))
+				a.setInfo(0, h-10, fmt.Sprintf("%s, Follow: %s", k.Help("%s: %s", life.ActionFollow, life.ActionFollowTarget), a.follow))
+				a.setInfo(0, h-9, fmt.Sprintf("%s/MiddleDrag: pan, %s/%s: zoom, %s, Zoom: 1:%d, View: %d,%d, Render: %s, Overlay: %s",
+					k.Join(life.ActionPanUp, life.ActionPanDown, life.ActionPanLeft, life.ActionPanRight),
+					k.Key(life.ActionZoomIn), k.Key(life.ActionZoomOut), k.Help("%s: %s", life.ActionRender, life.ActionOverlay),
+					a.view.zoom, a.view.x, a.view.y, a.render, a.overlay))
+				a.setInfo(0, h-7, fmt.Sprintf("%s, %s: move, %s",
+					k.Help("%s: %s", life.ActionCursor), k.Join(life.ActionUp, life.ActionDown, life.ActionLeft, life.ActionRight),
+					k.Help("%s: %s", life.ActionToggle, life.ActionInsert)))
+				a.setInfo(0, h-6, fmt.Sprintf("%s, Current: \"%s\"", k.Help("%s: %s", life.ActionTheme), a.Theme.Name()))
+				a.setInfo(0, h-5, fmt.Sprintf("%s, Stamp: %s",
+					k.Help("%s: %s", life.ActionPreset, life.ActionPresetPrevIn, life.ActionPresetNextIn, life.ActionCategory, life.ActionBrowse), a.stampName()))
+				a.setInfo(0, h-4, k.Help("%s: %s", life.ActionRotate, life.ActionFlipH, life.ActionFlipV))
+				a.setInfo(0, h-3, fmt.Sprintf("LeftClick: use tool, RightClick: insert stamp, %s", k.Help("%s: %s", life.ActionUndo, life.ActionRedo)))
+				a.setInfo(0, h-2, k.Help("%s: %s", life.ActionSelect, life.ActionCopy, life.ActionCut, life.ActionPaste, life.ActionDelete))
+				a.setInfo(0, h-1, k.Help("%s: %s", life.ActionPause, life.ActionStep, life.ActionBack, life.ActionClear, life.ActionRandom, life.ActionInfo))
 			}
 			a.screen.Show()
+			a.record()
 		}
 	}
 }
 
+// color of the screen for the rgb in its color mode.
+func (a *app) color(c life.RGB) tcell.Color {
+	switch a.colors {
+	case term.Mono:
+		return tcell.ColorDefault
+	case term.Color16:
+		return tcell.PaletteColor(term.Index16(c))
+	case term.Color256:
+		return tcell.PaletteColor(term.Index256(c))
+	default:
+		return rgbTo(c)
+	}
+}
+
 func rgbTo(rgb life.RGB) tcell.Color {
 	r, g, b := rgb.Color()
 	return tcell.NewRGBColor(int32(r), int32(g), int32(b))
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/amettod/life"
+	"github.com/amettod/life/term"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// thumbCols and thumbRows are the size of a list thumbnail in units.
+	thumbCols = 8
+	thumbRows = 2
+	// listCols is the width of the name, category, size and population of a
+	// preset.
+	listCols = 62
+	// previewCols and previewRows are the size of the live preview in units.
+	previewCols = 24
+	previewRows = 12
+)
+
+// board is a pattern stepped on its own.
+type board interface {
+	State() [][]int
+	Step()
+}
+
+// browser picks a preset from the ones whose names match the query.
+type browser struct {
+	on      bool
+	query   string
+	found   []int
+	pos     int
+	top     int
+	preview board
+}
+
+// browseKey handles the keys of the preset browser, reports whether it is
+// still open.
+func (a *app) browseKey(ev *tcell.EventKey, e chan<- event, t chan<- eventText, query *[]rune) bool {
+	act, ok := a.Keys.Browse(keyName(ev))
+	switch {
+	case ok:
+		e <- actions[act]
+		return act != life.ActionPick && act != life.ActionClose
+	case ev.Key() == tcell.KeyBackspace || ev.Key() == tcell.KeyBackspace2:
+		if len(*query) > 0 {
+			*query = (*query)[:len(*query)-1]
+			t <- eventText{e: eventBrowseQuery, text: string(*query)}
+		}
+	case ev.Key() == tcell.KeyRune:
+		*query = append(*query, ev.Rune())
+		t <- eventText{e: eventBrowseQuery, text: string(*query)}
+	}
+	return true
+}
+
+// openBrowser on the current preset.
+func (a *app) openBrowser() {
+	a.browser = browser{on: true}
+	a.filter("")
+}
+
+// filter the presets by the query, keeping the one picked if it matches.
+func (a *app) filter(query string) {
+	picked := a.Preset.Index()
+	if len(a.browser.found) > 0 {
+		picked = a.browser.found[a.browser.pos]
+	}
+	a.browser.query = query
+	a.browser.found = a.Preset.Find(query)
+	a.browser.pos, a.browser.top = 0, 0
+	for i, n := range a.browser.found {
+		if n == picked {
+			a.browser.pos = i
+		}
+	}
+	a.browsePreview()
+}
+
+// browseBy moves the pick by n presets.
+func (a *app) browseBy(n int) {
+	if len(a.browser.found) == 0 {
+		return
+	}
+	a.browser.pos = min(max(a.browser.pos+n, 0), len(a.browser.found)-1)
+	a.browsePreview()
+}
+
+func (a *app) browsePreview() {
+	a.browser.preview = nil
+	if len(a.browser.found) > 0 {
+		a.browser.preview = a.Preset.Preview(a.browser.found[a.browser.pos])
+	}
+}
+
+// browsePage is the number of presets the list shows.
+func (a *app) browsePage() int {
+	_, h := a.screen.Size()
+	return max((h-4)/thumbRows, 1)
+}
+
+// pick the preset as the current one and the stamp.
+func (a *app) pick() {
+	if len(a.browser.found) > 0 {
+		a.Preset.Select(a.browser.found[a.browser.pos])
+		a.stamp = a.Preset
+	}
+	a.browser = browser{}
+}
+
+// thumb renders the pattern in braille on cols by rows units at x y, each
+// dot standing for a square of cells shrunk to fit.
+func (a *app) thumb(s [][]int, x, y, cols, rows int, sd tcell.Style) {
+	w, h := life.Size(s)
+	k := max((w+cols*2-1)/(cols*2), (h+rows*4-1)/(rows*4), 1)
+	for uy := 0; uy < rows; uy++ {
+		for ux := 0; ux < cols; ux++ {
+			r := braille(func(dx, dy int) bool {
+				x0, y0 := (ux*2+dx)*k, (uy*4+dy)*k
+				for cy := y0; cy < min(y0+k, len(s)); cy++ {
+					for cx := x0; cx < min(x0+k, len(s[cy])); cx++ {
+						if s[cy][cx] > 0 {
+							return true
+						}
+					}
+				}
+				return false
+			})
+			a.screen.SetContent(x+ux, y+uy, r, nil, sd)
+		}
+	}
+}
+
+func (a *app) drawBrowser() {
+	sw, sh := a.screen.Size()
+	sd := a.style()
+	hl := sd.Background(a.color(life.Mix(a.Theme.Background(), a.Theme.Foreground(), 0.3)))
+	if a.colors == term.Mono {
+		hl = sd.Reverse(true)
+	}
+	for y := 0; y < sh; y++ {
+		for x := 0; x < sw; x++ {
+			a.screen.SetContent(x, y, ' ', nil, sd)
+		}
+	}
+
+	b := &a.browser
+	a.setInfo(1, 0, fmt.Sprintf("Presets: %s_", b.query))
+	k := a.Keys
+	a.setInfo(1, sh-1, fmt.Sprintf("%d of %d, %s, type to search", min(b.pos+1, len(b.found)), len(b.found),
+		k.Help("%s: %s", life.ActionBrowsePrev, life.ActionBrowseNext, life.ActionPageUp, life.ActionPageDown, life.ActionPick, life.ActionClose)))
+
+	page := a.browsePage()
+	if b.pos < b.top {
+		b.top = b.pos
+	}
+	if b.pos >= b.top+page {
+		b.top = b.pos - page + 1
+	}
+	for row, i := 0, b.top; row < page && i < len(b.found); row, i = row+1, i+1 {
+		n := b.found[i]
+		s := a.Preset.StateAt(n)
+		w, h := life.Size(s)
+		st := sd
+		if i == b.pos {
+			st = hl
+		}
+		y := 2 + row*thumbRows
+		a.thumb(s, 1, y, thumbCols, thumbRows, st)
+		text := fmt.Sprintf(" %-24.24s %-13.13s %4dx%-4d %5d cells ",
+			a.Preset.NameAt(n), a.Preset.CategoryAt(n), w, h, life.Population(s))
+		for j, r := range text {
+			a.screen.SetContent(1+thumbCols+j, y, r, nil, st)
+		}
+	}
+
+	// The live preview goes right of the list when there is room.
+	px := 2 + thumbCols + listCols
+	if b.preview != nil && px+previewCols <= sw && previewRows+2 <= sh {
+		a.thumb(b.preview.State(), px, 2, previewCols, previewRows, sd)
+	}
+}
@@ -1,5 +1,7 @@
 package main
 
+import "github.com/amettod/life"
+
 type event uint
 
 const (
@@ -12,10 +14,122 @@ const (
 	eventInfo
 	eventPreset
 	eventTheme
-	eventShift
+	eventUndo
+	eventRedo
+	eventBack
+	eventSelect
+	eventCopy
+	eventCut
+	eventPaste
+	eventDelete
+	eventRotate
+	eventFlipH
+	eventFlipV
 	eventInsert
+	eventPress
+	eventDrag
+	eventRelease
+	eventMove
+	eventInk
+	eventCursor
+	eventUp
+	eventDown
+	eventLeft
+	eventRight
+	eventToggle
+	eventStamp
+	eventRender
+	eventOverlay
+	eventFollow
+	eventFollowTarget
+	eventFaster
+	eventSlower
+	eventStepN
+	eventGoto
+	eventPasteText
+	eventPromptEdit
+	eventPresetNextIn
+	eventPresetPrevIn
+	eventPresetCategory
+	eventBrowse
+	eventBrowsePrev
+	eventBrowseNext
+	eventBrowsePageUp
+	eventBrowsePageDown
+	eventBrowsePick
+	eventBrowseClose
+	eventBrowseQuery
+	eventZoomIn
+	eventZoomOut
+	eventPanUp
+	eventPanDown
+	eventPanLeft
+	eventPanRight
+	eventPanStart
+	eventPanDrag
+	// eventTool is followed by one event per tool.
+	eventTool
 )
 
+// actions are the events of the key bindings, the ones missing take more
+// than an event.
+var actions = map[life.Action]event{
+	life.ActionQuit:         eventQuit,
+	life.ActionPause:        eventPause,
+	life.ActionStep:         eventStep,
+	life.ActionBack:         eventBack,
+	life.ActionClear:        eventClear,
+	life.ActionRandom:       eventRandom,
+	life.ActionTheme:        eventTheme,
+	life.ActionPreset:       eventPreset,
+	life.ActionPresetNextIn: eventPresetNextIn,
+	life.ActionPresetPrevIn: eventPresetPrevIn,
+	life.ActionCategory:     eventPresetCategory,
+	life.ActionInsert:       eventStamp,
+	life.ActionInfo:         eventInfo,
+	life.ActionUndo:         eventUndo,
+	life.ActionRedo:         eventRedo,
+	life.ActionFaster:       eventFaster,
+	life.ActionSlower:       eventSlower,
+	life.ActionUp:           eventUp,
+	life.ActionDown:         eventDown,
+	life.ActionLeft:         eventLeft,
+	life.ActionRight:        eventRight,
+	life.ActionToggle:       eventToggle,
+	life.ActionSelect:       eventSelect,
+	life.ActionCopy:         eventCopy,
+	life.ActionCut:          eventCut,
+	life.ActionPaste:        eventPaste,
+	life.ActionDelete:       eventDelete,
+	life.ActionRotate:       eventRotate,
+	life.ActionFlipH:        eventFlipH,
+	life.ActionFlipV:        eventFlipV,
+	life.ActionInk:          eventInk,
+	life.ActionZoomIn:       eventZoomIn,
+	life.ActionZoomOut:      eventZoomOut,
+	life.ActionRender:       eventRender,
+	life.ActionOverlay:      eventOverlay,
+	life.ActionFollow:       eventFollow,
+	life.ActionFollowTarget: eventFollowTarget,
+	life.ActionPanUp:        eventPanUp,
+	life.ActionPanDown:      eventPanDown,
+	life.ActionPanLeft:      eventPanLeft,
+	life.ActionPanRight:     eventPanRight,
+	life.ActionToolToggle:   eventTool + event(toolToggle),
+	life.ActionToolSelect:   eventTool + event(toolSelect),
+	life.ActionToolPen:      eventTool + event(toolPen),
+	life.ActionToolLine:     eventTool + event(toolLine),
+	life.ActionToolRect:     eventTool + event(toolRect),
+	life.ActionToolBox:      eventTool + event(toolBox),
+	life.ActionToolFill:     eventTool + event(toolFill),
+	life.ActionBrowsePrev:   eventBrowsePrev,
+	life.ActionBrowseNext:   eventBrowseNext,
+	life.ActionPageUp:       eventBrowsePageUp,
+	life.ActionPageDown:     eventBrowsePageDown,
+	life.ActionPick:         eventBrowsePick,
+	life.ActionClose:        eventBrowseClose,
+}
+
 type eventPoint struct {
 	e    event
 	x, y int
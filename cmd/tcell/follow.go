@@ -0,0 +1,101 @@
+package main
+
+import "math"
+
+// followMargin is how far the tracked object may move out of its region in
+// a generation, spaceships are never faster than a cell.
+const followMargin = 2
+
+// follow keeps the view on the population, or on the object selected when
+// the camera was locked.
+type follow struct {
+	on bool
+	// bounds tracks the center of the bounding box instead of the centroid.
+	bounds bool
+	// region around the tracked object and the centroid offset inside it.
+	region     selection
+	offX, offY float64
+}
+
+func (f follow) String() string {
+	target := "centroid"
+	if f.bounds {
+		target = "bounds"
+	}
+	switch {
+	case !f.on:
+		return "off"
+	case f.region.ok:
+		return "object " + target
+	default:
+		return "population " + target
+	}
+}
+
+// lock the camera, on the selected object if there is one.
+func (a *app) lock() {
+	a.follow.on = !a.follow.on
+	a.follow.region = selection{}
+	if !a.follow.on || !a.sel.ok {
+		return
+	}
+	r := selection{
+		x0: min(a.sel.x0, a.sel.x1),
+		y0: min(a.sel.y0, a.sel.y1),
+		x1: max(a.sel.x0, a.sel.x1),
+		y1: max(a.sel.y0, a.sel.y1),
+		ok: true,
+	}
+	if cx, cy, ok := a.aim(r); ok {
+		a.follow.region = r
+		a.follow.offX, a.follow.offY = cx-float64(r.x0), cy-float64(r.y0)
+	}
+}
+
+// aim of the camera inside the region.
+func (a *app) aim(r selection) (float64, float64, bool) {
+	x0, y0 := r.x0-followMargin, r.y0-followMargin
+	x1, y1 := r.x1+followMargin, r.y1+followMargin
+	if !a.follow.bounds {
+		return a.Game.Centroid(x0, y0, x1, y1)
+	}
+	bx0, by0, bx1, by1, ok := a.Game.Bounds(x0, y0, x1, y1)
+	return float64(bx0+bx1) / 2, float64(by0+by1) / 2, ok
+}
+
+// track the target and center the view on it.
+func (a *app) track() {
+	if !a.follow.on {
+		return
+	}
+	var (
+		cx, cy float64
+		ok     bool
+	)
+	switch {
+	case a.follow.region.ok:
+		r := &a.follow.region
+		if cx, cy, ok = a.aim(*r); ok {
+			dx := int(math.Round(cx-a.follow.offX)) - r.x0
+			dy := int(math.Round(cy-a.follow.offY)) - r.y0
+			// The region moves with the object and wraps with the board.
+			dx = mod(r.x0+dx, a.Game.Width()) - r.x0
+			dy = mod(r.y0+dy, a.Game.Height()) - r.y0
+			r.x0, r.x1, r.y0, r.y1 = r.x0+dx, r.x1+dx, r.y0+dy, r.y1+dy
+			a.sel = *r
+		}
+	case a.follow.bounds:
+		var x0, y0, x1, y1 int
+		x0, y0, x1, y1, ok = a.Game.Bounds(0, 0, a.Game.Width()-1, a.Game.Height()-1)
+		cx, cy = float64(x0+x1)/2, float64(y0+y1)/2
+	default:
+		cx, cy, ok = a.Game.Center()
+	}
+	if !ok {
+		return
+	}
+	w, h := a.span()
+	a.view.x = int(math.Round(cx)) - w/2
+	a.view.y = int(math.Round(cy)) - h/2
+	a.clampView()
+}
@@ -3,21 +3,43 @@ package main
 import (
 	"flag"
 	"log"
+	"os"
+
+	"github.com/amettod/life"
+	"github.com/amettod/life/term"
 )
 
 func main() {
-	f := flag.String("f", "", "pattern filename")
-	d := flag.Duration("d", 100, "duration of the screen refresh period in milliseconds")
-	flag.Parse()
+	fl := life.NewFlags(flag.CommandLine, 0, 0)
+	flag.Lookup("w").Usage = "board width, the screen width if zero"
+	flag.Lookup("h").Usage = "board height, the screen height if zero"
+	rd := flag.String("render", "block", "render mode: block, half or braille")
+	run, err := fl.Parse(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !run {
+		return
+	}
+
+	mode, err := term.ParseMode(fl.Colors)
+	if err != nil {
+		log.Fatal(err)
+	}
+	r, err := parseRender(*rd)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	a, err := newApp(*f, *d, 2)
+	a, err := newApp(fl.Width, fl.Height, fl.File, 2, fl.Rec, mode, r, fl.Options()...)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	e := make(chan event)
 	ep := make(chan eventPoint)
+	et := make(chan eventText)
 
-	go a.waitEvent(e, ep)
-	a.doEvent(e, ep)
+	go a.waitEvent(e, ep, et)
+	a.doEvent(e, ep, et)
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"math"
+
+	"github.com/amettod/life"
+)
+
+type overlay uint
+
+const (
+	overlayOff overlay = iota
+	overlayHeat
+	overlayHistory
+	overlayCount
+)
+
+func (o overlay) String() string {
+	switch o {
+	case overlayHeat:
+		return "heatmap"
+	case overlayHistory:
+		return "history"
+	default:
+		return "off"
+	}
+}
+
+// heat colors a cell that is not alive by the generations it has been:
+// the heatmap grows towards the color of the young cells, the history
+// marks the envelope of all the cells that have ever been alive.
+func (a *app) heat(c life.RGB, x, y int) life.RGB {
+	if a.overlay == overlayOff {
+		return c
+	}
+	n := a.Game.Heat()[y][x]
+	if n == 0 {
+		return c
+	}
+	bg := a.Theme.Background()
+	if a.overlay == overlayHistory {
+		return life.Mix(bg, a.Theme.Foreground(), 0.25)
+	}
+	t := math.Log1p(float64(n)) / math.Log1p(float64(max(a.heatMax, 1)))
+	return life.Mix(bg, a.Theme.Color(1), 0.15+0.7*t)
+}
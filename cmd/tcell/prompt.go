@@ -0,0 +1,57 @@
+package main
+
+import (
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type eventText struct {
+	e    event
+	text string
+}
+
+// prompt reads a line of text in place of the key bindings.
+type prompt struct {
+	// e is sent with the text on Enter.
+	e      event
+	label  string
+	text   []rune
+	digits bool
+}
+
+func newPrompt(e event, label string, digits bool) *prompt {
+	return &prompt{
+		e:      e,
+		label:  label,
+		digits: digits,
+	}
+}
+
+// edit shows the prompt.
+func (p *prompt) edit(t chan<- eventText) {
+	t <- eventText{e: eventPromptEdit, text: p.label + string(p.text)}
+}
+
+// key edits the prompt, reports whether it is still open.
+func (p *prompt) key(ev *tcell.EventKey, t chan<- eventText) bool {
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		t <- eventText{e: eventPromptEdit}
+		t <- eventText{e: p.e, text: string(p.text)}
+		return false
+	case tcell.KeyEsc, tcell.KeyCtrlC:
+		t <- eventText{e: eventPromptEdit}
+		return false
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(p.text) > 0 {
+			p.text = p.text[:len(p.text)-1]
+		}
+	case tcell.KeyRune:
+		if !p.digits || unicode.IsDigit(ev.Rune()) {
+			p.text = append(p.text, ev.Rune())
+		}
+	}
+	p.edit(t)
+	return true
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/amettod/life"
+	"github.com/amettod/life/term"
+	"github.com/gdamore/tcell/v2"
+)
+
+// recorder replays the screen contents as ANSI frames into a cast file.
+type recorder struct {
+	term term.Term
+	file io.Closer
+}
+
+func newRecorder(name string, w, h int, colors term.Mode) (*recorder, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	cast, err := term.NewCast(f, w, h+1)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &recorder{
+		term: term.New(cast, colors),
+		file: f,
+	}, nil
+}
+
+// record the screen as it is shown.
+func (a *app) record() {
+	if a.rec == nil {
+		return
+	}
+	w, h := a.screen.Size()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, _, style, _ := a.screen.GetContent(x, y)
+			fg, bg, _ := style.Decompose()
+			a.rec.term.Write(
+				colorTo(bg, a.Theme.Background()),
+				colorTo(fg, a.Theme.Foreground()),
+				string(r),
+			)
+		}
+		a.rec.term.WriteLn()
+	}
+	a.rec.term.Print()
+}
+
+func (r *recorder) Close() error {
+	return r.file.Close()
+}
+
+// colorTo is the reverse of rgbTo, with the default for unset colors.
+func colorTo(c tcell.Color, d life.RGB) life.RGB {
+	if c == tcell.ColorDefault {
+		return d
+	}
+	r, g, b := c.RGB()
+	return life.NewRGB(uint8(r), uint8(g), uint8(b))
+}
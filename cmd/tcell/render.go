@@ -0,0 +1,260 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/amettod/life"
+	"github.com/amettod/life/term"
+	"github.com/gdamore/tcell/v2"
+)
+
+type render uint
+
+const (
+	renderBlock render = iota
+	renderHalf
+	renderBraille
+	renderCount
+)
+
+// parseRender reads the render by the first word of its String.
+func parseRender(s string) (render, error) {
+	for r := renderBlock; r < renderCount; r++ {
+		if s == strings.Fields(r.String())[0] {
+			return r, nil
+		}
+	}
+	return renderBlock, fmt.Errorf("render %q is not block, half or braille", s)
+}
+
+func (r render) String() string {
+	switch r {
+	case renderHalf:
+		return "half block"
+	case renderBraille:
+		return "braille"
+	default:
+		return "block"
+	}
+}
+
+// dots is the number of cell blocks a unit shows across and down.
+func (r render) dots() (int, int) {
+	switch r {
+	case renderHalf:
+		return 1, 2
+	case renderBraille:
+		return 2, 4
+	default:
+		return 1, 1
+	}
+}
+
+// shades of the block density when zoomed out.
+var shades = []rune{' ', '░', '▒', '▓', '█'}
+
+// brailleBits of the dots by column and row.
+var brailleBits = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// braille glyph with the dots for which alive is true.
+func braille(alive func(dx, dy int) bool) rune {
+	r := rune(0x2800)
+	for dx := range brailleBits {
+		for dy, bit := range brailleBits[dx] {
+			if alive(dx, dy) {
+				r |= bit
+			}
+		}
+	}
+	return r
+}
+
+// marks over a cell that tint its color.
+type marks struct {
+	sel, ghost, cursor bool
+}
+
+func (m marks) or(o marks) marks {
+	return marks{
+		sel:    m.sel || o.sel,
+		ghost:  m.ghost || o.ghost,
+		cursor: m.cursor || o.cursor,
+	}
+}
+
+// marks of the selection, the stamp preview and the cursor over the cell.
+func (a *app) marks(x, y int) marks {
+	return marks{
+		sel:    a.sel.inside(x, y),
+		ghost:  a.ghost(x, y),
+		cursor: a.cursor && a.render != renderBlock && a.under(a.cursorX, a.cursorY, x, y),
+	}
+}
+
+// tintBy mixes the color once per mark.
+func (a *app) tintBy(c life.RGB, m marks) life.RGB {
+	if m.sel {
+		c = life.Mix(c, a.Theme.Foreground(), 0.3)
+	}
+	if m.ghost {
+		c = life.Mix(c, a.Theme.Foreground(), 0.5)
+	}
+	if m.cursor {
+		c = life.Mix(c, a.Theme.Foreground(), 0.7)
+	}
+	return c
+}
+
+// tint marks the selection, the stamp preview and the cursor.
+func (a *app) tint(c life.RGB, x, y int) life.RGB {
+	return a.tintBy(c, a.marks(x, y))
+}
+
+func (a *app) draw() {
+	a.screen.Clear()
+	if a.overlay != overlayOff {
+		a.heatMax = a.Game.HeatMax()
+	}
+	switch a.render {
+	case renderHalf:
+		a.drawHalf()
+	case renderBraille:
+		a.drawBraille()
+	default:
+		a.drawBlocks()
+	}
+}
+
+// drawBlocks renders a unit per cell block, zoomed out blocks are shaded by
+// their density.
+func (a *app) drawBlocks() {
+	w, h := a.units()
+	for uy := 0; uy < h; uy++ {
+		for ux := 0; ux < w; ux++ {
+			x, y, ok := a.dotCell(ux, uy, 0, 0)
+			if !ok {
+				continue
+			}
+			bg := a.Theme.Background()
+			fg := a.Theme.Foreground()
+			l, r := ' ', ' '
+			if a.view.zoom == 1 {
+				bg = a.Theme.Color(a.Game.State()[y][x])
+				if a.Game.State()[y][x] <= 0 {
+					bg = a.heat(bg, x, y)
+				}
+				if a.colors == term.Mono {
+					l = a.Theme.Shade(a.Game.State()[y][x])
+					r = l
+				}
+			} else {
+				alive, cycle := a.block(x, y)
+				if alive > 0 {
+					fg = a.Theme.Color(cycle)
+					i := (alive*(len(shades)-1) + a.view.zoom*a.view.zoom - 1) / (a.view.zoom * a.view.zoom)
+					l, r = shades[i], shades[i]
+				} else {
+					bg = a.heat(a.Theme.Color(cycle), x, y)
+				}
+			}
+			bg = a.tint(bg, x, y)
+			if a.cursor && a.under(a.cursorX, a.cursorY, x, y) {
+				l, r = '[', ']'
+				fg = a.Theme.Foreground()
+			}
+			sd := tcell.StyleDefault.
+				Background(a.color(bg)).
+				Foreground(a.color(fg))
+			if a.colors == term.Mono && (a.sel.inside(x, y) || a.ghost(x, y)) {
+				sd = sd.Reverse(true)
+			}
+			a.screen.SetContent(ux*a.rate, uy, l, nil, sd)
+			a.screen.SetContent(ux*a.rate+1, uy, r, nil, sd)
+		}
+	}
+}
+
+// halves are the glyphs of the alive upper and lower halves without colors.
+var halves = [2][2]rune{
+	{' ', '▄'},
+	{'▀', '█'},
+}
+
+// drawHalf renders two cell blocks per character, the upper half block in
+// the foreground and the lower in the background color.
+func (a *app) drawHalf() {
+	w, h := a.units()
+	for uy := 0; uy < h; uy++ {
+		for ux := 0; ux < w; ux++ {
+			var c [2]life.RGB
+			var alive [2]int
+			for dy := range c {
+				c[dy] = a.Theme.Background()
+				if x, y, ok := a.dotCell(ux, uy, 0, dy); ok {
+					n, cycle := a.block(x, y)
+					c[dy] = a.Theme.Color(cycle)
+					if n == 0 {
+						c[dy] = a.heat(c[dy], x, y)
+					}
+					c[dy] = a.tint(c[dy], x, y)
+					alive[dy] = min(n, 1)
+				}
+			}
+			if a.colors == term.Mono {
+				a.screen.SetContent(ux, uy, halves[alive[0]][alive[1]], nil, tcell.StyleDefault)
+				continue
+			}
+			a.screen.SetContent(ux, uy, '▀', nil, tcell.StyleDefault.
+				Foreground(a.color(c[0])).
+				Background(a.color(c[1])))
+		}
+	}
+}
+
+// drawBraille renders two by four cell blocks per character as dots in the
+// color of the youngest alive block.
+func (a *app) drawBraille() {
+	w, h := a.units()
+	for uy := 0; uy < h; uy++ {
+		for ux := 0; ux < w; ux++ {
+			color, dead := 0, 0
+			// hot is the dot not alive that has been the longest.
+			hot, hotX, hotY := 0, 0, 0
+			bg := a.Theme.Background()
+			// m gathers the marks of the dots, to tint the glyph once.
+			var m marks
+			r := braille(func(dx, dy int) bool {
+				x, y, ok := a.dotCell(ux, uy, dx, dy)
+				if !ok {
+					return false
+				}
+				alive, cycle := a.block(x, y)
+				if alive > 0 && (color == 0 || cycle < color) {
+					color = cycle
+				}
+				if alive == 0 && cycle < 0 && (dead == 0 || cycle > dead) {
+					dead = cycle
+				}
+				if n := a.Game.Heat()[y][x]; alive == 0 && a.overlay != overlayOff && n > hot {
+					hot, hotX, hotY = n, x, y
+				}
+				m = m.or(a.marks(x, y))
+				return alive > 0
+			})
+			if dead != 0 {
+				bg = a.Theme.Color(dead)
+			}
+			if hot > 0 && dead == 0 {
+				bg = a.heat(bg, hotX, hotY)
+			}
+			bg = a.tintBy(bg, m)
+			a.screen.SetContent(ux, uy, r, nil, tcell.StyleDefault.
+				Foreground(a.color(a.Theme.Color(color))).
+				Background(a.color(bg)))
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amettod/life"
+	"github.com/amettod/life/term"
+)
+
+type tool uint
+
+const (
+	toolToggle tool = iota
+	toolSelect
+	toolPen
+	toolLine
+	toolRect
+	toolBox
+	toolFill
+	toolCount
+)
+
+func (t tool) String() string {
+	switch t {
+	case toolSelect:
+		return "select"
+	case toolPen:
+		return "pen"
+	case toolLine:
+		return "line"
+	case toolRect:
+		return "rectangle"
+	case toolBox:
+		return "filled rectangle"
+	case toolFill:
+		return "flood fill"
+	default:
+		return "toggle"
+	}
+}
+
+// toolActions select the tools.
+var toolActions = [toolCount]life.Action{
+	toolToggle: life.ActionToolToggle,
+	toolSelect: life.ActionToolSelect,
+	toolPen:    life.ActionToolPen,
+	toolLine:   life.ActionToolLine,
+	toolRect:   life.ActionToolRect,
+	toolBox:    life.ActionToolBox,
+	toolFill:   life.ActionToolFill,
+}
+
+// palette lists the tools by their keys, the current one in brackets.
+func (a *app) palette() string {
+	var b strings.Builder
+	for t := toolToggle; t < toolCount; t++ {
+		format := " %s %s"
+		if t == a.tool {
+			format = " [%s %s]"
+		}
+		fmt.Fprintf(&b, format, a.Keys.Key(toolActions[t]), t)
+	}
+	ink := "draw"
+	if !a.ink {
+		ink = "erase"
+	}
+	return fmt.Sprintf("Tools:%s, %s: %s", b.String(), a.Keys.Key(life.ActionInk), ink)
+}
+
+// stamp is the pattern a right click inserts.
+type stamp interface {
+	State() [][]int
+	Rotate()
+	FlipH()
+	FlipV()
+}
+
+type selection struct {
+	x0, y0 int
+	x1, y1 int
+	ok     bool
+}
+
+func (s selection) inside(x, y int) bool {
+	return s.ok &&
+		x >= min(s.x0, s.x1) && x <= max(s.x0, s.x1) &&
+		y >= min(s.y0, s.y1) && y <= max(s.y0, s.y1)
+}
+
+// use the tool at cell x y for a mouse press, drag or release.
+func (a *app) use(e event, x, y int) {
+	if e == eventPress {
+		// An edit left open lost its release outside the screen.
+		a.Game.Commit()
+	}
+	switch a.tool {
+	case toolToggle:
+		if e == eventPress {
+			a.Game.Shift(x, y)
+		}
+	case toolSelect:
+		switch e {
+		case eventPress:
+			a.sel = selection{x0: x, y0: y, x1: x, y1: y, ok: true}
+		case eventDrag, eventRelease:
+			a.sel.x1, a.sel.y1 = x, y
+		}
+	case toolPen:
+		switch e {
+		case eventPress:
+			a.Game.Begin()
+			a.Game.Set(x, y, a.ink)
+		case eventDrag, eventRelease:
+			a.Game.Line(a.fromX, a.fromY, x, y, a.ink)
+		}
+		a.fromX, a.fromY = x, y
+		if e == eventRelease {
+			a.Game.Commit()
+		}
+	case toolLine, toolRect, toolBox:
+		// The shape is redrawn from the start of the edit while dragging.
+		if e == eventPress {
+			a.Game.Begin()
+			a.fromX, a.fromY = x, y
+		}
+		a.Game.Revert()
+		switch a.tool {
+		case toolLine:
+			a.Game.Line(a.fromX, a.fromY, x, y, a.ink)
+		default:
+			a.Game.Rect(a.fromX, a.fromY, x, y, a.ink, a.tool == toolBox)
+		}
+		if e == eventRelease {
+			a.Game.Commit()
+		}
+	case toolFill:
+		if e == eventPress {
+			a.Game.Fill(x, y, a.ink)
+		}
+	}
+}
+
+// copySelection to the clipboard, the system one included.
+func (a *app) copySelection() bool {
+	if !a.sel.ok {
+		return false
+	}
+	a.Clipboard.Set(a.Game.Copy(a.sel.x0, a.sel.y0, a.sel.x1, a.sel.y1))
+	term.Clipboard(os.Stdout, a.Clipboard.Text())
+	a.stamp = a.Clipboard
+	return true
+}
+
+func (a *app) cutSelection() {
+	if a.copySelection() {
+		a.Game.ClearRect(a.sel.x0, a.sel.y0, a.sel.x1, a.sel.y1)
+	}
+}
+
+func (a *app) deleteSelection() {
+	if a.sel.ok {
+		a.Game.ClearRect(a.sel.x0, a.sel.y0, a.sel.x1, a.sel.y1)
+	}
+}
+
+func (a *app) paste() {
+	if x, y, ok := a.target(); ok && !a.Clipboard.Empty() {
+		a.Game.SetState(x, y, a.Clipboard.State())
+		a.stamp = a.Clipboard
+	}
+}
+
+// target is the cell under the keyboard cursor, or else the mouse.
+func (a *app) target() (int, int, bool) {
+	if a.cursor {
+		return a.cursorX, a.cursorY, true
+	}
+	return a.mouseX, a.mouseY, a.mouseOK
+}
+
+func (a *app) moveCursor(dx, dy int) {
+	a.cursorX = min(max(a.cursorX+dx, 0), a.Game.Width()-1)
+	a.cursorY = min(max(a.cursorY+dy, 0), a.Game.Height()-1)
+	a.reveal(a.cursorX, a.cursorY)
+}
+
+func (a *app) stampName() string {
+	if a.stamp == a.Clipboard {
+		return "clipboard"
+	}
+	return fmt.Sprintf("preset \"%s\"", a.Preset.Name())
+}
+
+// ghost previews the stamp under the mouse.
+func (a *app) ghost(x, y int) bool {
+	tx, ty, ok := a.target()
+	if a.tool != toolToggle || !ok {
+		return false
+	}
+	s := a.stamp.State()
+	x, y = x-tx, y-ty
+	return y >= 0 && y < len(s) && x >= 0 && x < len(s[y]) && s[y][x] > 0
+}
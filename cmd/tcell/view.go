@@ -0,0 +1,156 @@
+package main
+
+const maxZoom = 16
+
+// view is the part of the board on the screen.
+type view struct {
+	// x y is the top left cell.
+	x, y int
+	// zoom is the side of the cell block behind a dot of the render.
+	zoom int
+}
+
+func mod(a, b int) int {
+	if b == 0 {
+		return 0
+	}
+	return (a%b + b) % b
+}
+
+// cols is the number of screen columns of a unit.
+func (a *app) cols() int {
+	if a.render == renderBlock {
+		return a.rate
+	}
+	return 1
+}
+
+// units of the screen, a unit is a character of the render.
+func (a *app) units() (int, int) {
+	w, h := a.screen.Size()
+	return w / a.cols(), h
+}
+
+// step is the number of cells behind a unit.
+func (a *app) step() (int, int) {
+	dw, dh := a.render.dots()
+	return dw * a.view.zoom, dh * a.view.zoom
+}
+
+// span is the number of cells on the screen.
+func (a *app) span() (int, int) {
+	w, h := a.units()
+	sx, sy := a.step()
+	return w * sx, h * sy
+}
+
+// fitSize is the board size that fills the screen without zoom.
+func (a *app) fitSize() (int, int) {
+	w, h := a.units()
+	dw, dh := a.render.dots()
+	return w * dw, h * dh
+}
+
+// wraps reports whether the view is smaller than the board, so it wraps
+// around the edges instead of showing the board as a whole.
+func (a *app) wraps() (bool, bool) {
+	w, h := a.span()
+	return a.Game.Width() > w, a.Game.Height() > h
+}
+
+// clampView keeps the view origin on the board.
+func (a *app) clampView() {
+	wx, wy := a.wraps()
+	a.view.x = mod(a.view.x, a.Game.Width())
+	a.view.y = mod(a.view.y, a.Game.Height())
+	if !wx {
+		a.view.x = 0
+	}
+	if !wy {
+		a.view.y = 0
+	}
+}
+
+// dotCell is the top left cell of the block behind the dot dx dy of the
+// unit ux uy.
+func (a *app) dotCell(ux, uy, dx, dy int) (int, int, bool) {
+	sx, sy := a.step()
+	x := a.view.x + ux*sx + dx*a.view.zoom
+	y := a.view.y + uy*sy + dy*a.view.zoom
+	wx, wy := a.wraps()
+	if !wx && x >= a.Game.Width() || !wy && y >= a.Game.Height() {
+		return 0, 0, false
+	}
+	return mod(x, a.Game.Width()), mod(y, a.Game.Height()), true
+}
+
+// cell under the screen position x y.
+func (a *app) cell(x, y int) (int, int) {
+	cx, cy, _ := a.dotCell(x/a.cols(), y, 0, 0)
+	return cx, cy
+}
+
+func (a *app) pan(dx, dy int) {
+	a.view.x += dx
+	a.view.y += dy
+	a.clampView()
+}
+
+// panUnits pans by screen units.
+func (a *app) panUnits(dx, dy int) {
+	sx, sy := a.step()
+	a.pan(dx*sx, dy*sy)
+}
+
+// zoomBy multiplies the zoom by f keeping the center of the screen.
+func (a *app) zoomBy(f float64) {
+	z := int(float64(a.view.zoom) * f)
+	if z < 1 || z > maxZoom {
+		return
+	}
+	w, h := a.span()
+	cx, cy := a.view.x+w/2, a.view.y+h/2
+	a.view.zoom = z
+	w, h = a.span()
+	a.view.x, a.view.y = cx-w/2, cy-h/2
+	a.clampView()
+}
+
+// under reports whether the cell cx cy is in the block at x y.
+func (a *app) under(cx, cy, x, y int) bool {
+	return mod(cx-x, a.Game.Width()) < a.view.zoom && mod(cy-y, a.Game.Height()) < a.view.zoom
+}
+
+// reveal pans the view so the cell x y is on the screen.
+func (a *app) reveal(x, y int) {
+	w, h := a.span()
+	if rx := mod(x-a.view.x, a.Game.Width()); rx >= w {
+		a.view.x = x - w/2
+	}
+	if ry := mod(y-a.view.y, a.Game.Height()); ry >= h {
+		a.view.y = y - h/2
+	}
+	a.clampView()
+}
+
+// block of cells behind a dot: the count of alive cells and the cycle that
+// colors it, the youngest alive or else the latest dead.
+func (a *app) block(x, y int) (int, int) {
+	s := a.Game.State()
+	alive, color := 0, 0
+	for dy := 0; dy < a.view.zoom; dy++ {
+		for dx := 0; dx < a.view.zoom; dx++ {
+			cycle := s[mod(y+dy, len(s))][mod(x+dx, len(s[0]))]
+			switch {
+			case cycle > 0:
+				alive++
+				if color <= 0 || cycle < color {
+					color = cycle
+				}
+			case cycle < 0 && (color == 0 || color < 0 && cycle > color):
+				color = cycle
+			}
+		}
+	}
+	return alive, color
+}
@@ -0,0 +1,146 @@
+package life
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const appName = "life"
+
+// Config of the front-ends, read from a JSON file. Each field stands for
+// the command line flag it sets.
+type Config struct {
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Period string `json:"period,omitempty"`
+	Rewind int    `json:"rewind,omitempty"`
+	Theme  string `json:"theme,omitempty"`
+	Preset string `json:"preset,omitempty"`
+	Render string `json:"render,omitempty"`
+	Colors string `json:"colors,omitempty"`
+	// Presets are directories of user presets.
+	Presets []string `json:"presets,omitempty"`
+	// Keys bind the actions by name to the keys in place of their own.
+	Keys map[string][]string `json:"keys,omitempty"`
+}
+
+// xdgDir is the base directory in the environment variable, or else the
+// fallback under the home directory.
+func xdgDir(env, fallback string) string {
+	if d := os.Getenv(env); d != "" {
+		return d
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, fallback)
+}
+
+// ConfigPath is the config file in the XDG config directory.
+func ConfigPath() string {
+	d := xdgDir("XDG_CONFIG_HOME", ".config")
+	if d == "" {
+		return ""
+	}
+	return filepath.Join(d, appName, "config.json")
+}
+
+// presetDir holds the user presets in the XDG data directory.
+func presetDir() string {
+	d := xdgDir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+	if d == "" {
+		return ""
+	}
+	return filepath.Join(d, appName, "presets")
+}
+
+// LoadConfig reads the config file, the error wraps fs.ErrNotExist when it
+// is missing.
+func LoadConfig(name string) (Config, error) {
+	var c Config
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return c, fmt.Errorf("read config: %w", err)
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, fmt.Errorf("parse config %s: %w", name, err)
+	}
+	return c, nil
+}
+
+// flags are the values of the config by flag name, the unset ones left out.
+func (c Config) flags() map[string]string {
+	f := map[string]string{}
+	for name, n := range map[string]int{"w": c.Width, "h": c.Height, "rewind": c.Rewind} {
+		if n != 0 {
+			f[name] = strconv.Itoa(n)
+		}
+	}
+	for name, s := range map[string]string{"d": c.Period, "theme": c.Theme, "preset": c.Preset, "render": c.Render, "colors": c.Colors} {
+		if s != "" {
+			f[name] = s
+		}
+	}
+	if len(c.Presets) > 0 {
+		f["presets"] = strings.Join(c.Presets, string(os.PathListSeparator))
+	}
+	return f
+}
+
+// Apply the config to the flags the command line left unset, the ones the
+// set lacks are skipped.
+func (c Config) Apply(set *flag.FlagSet) error {
+	given := map[string]bool{}
+	set.Visit(func(f *flag.Flag) {
+		given[f.Name] = true
+	})
+	for name, value := range c.flags() {
+		if given[name] || set.Lookup(name) == nil {
+			continue
+		}
+		if err := set.Set(name, value); err != nil {
+			return fmt.Errorf("config -%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ConfigOf the flags, the effective config once it is applied.
+func ConfigOf(set *flag.FlagSet) Config {
+	var c Config
+	value := func(name string) string {
+		if f := set.Lookup(name); f != nil {
+			return f.Value.String()
+		}
+		return ""
+	}
+	c.Width, _ = strconv.Atoi(value("w"))
+	c.Height, _ = strconv.Atoi(value("h"))
+	c.Rewind, _ = strconv.Atoi(value("rewind"))
+	c.Period = value("d")
+	c.Theme = value("theme")
+	c.Preset = value("preset")
+	c.Render = value("render")
+	c.Colors = value("colors")
+	if p := value("presets"); p != "" {
+		c.Presets = filepath.SplitList(p)
+	}
+	return c
+}
+
+// Write the config as JSON.
+func (c Config) Write(w io.Writer) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
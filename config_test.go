@@ -0,0 +1,53 @@
+package life
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_Config_Apply(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		args []string
+		want Config
+	}{
+		{
+			name: "defaults",
+			cfg:  Config{},
+			want: Config{Width: 40, Height: 23, Period: "100ms", Theme: "matrix"},
+		},
+		{
+			name: "config",
+			cfg:  Config{Width: 80, Period: "50ms", Theme: "fire", Render: "half", Presets: []string{"a", "b"}},
+			want: Config{Width: 80, Height: 23, Period: "50ms", Theme: "fire", Presets: []string{"a", "b"}},
+		},
+		{
+			name: "flags take precedence",
+			cfg:  Config{Width: 80, Theme: "fire"},
+			args: []string{"-w", "60", "-theme", "ocean"},
+			want: Config{Width: 60, Height: 23, Period: "100ms", Theme: "ocean"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			set.Int("w", 40, "")
+			set.Int("h", 23, "")
+			set.Duration("d", 100*time.Millisecond, "")
+			set.String("theme", "matrix", "")
+			set.String("presets", "", "")
+			if err := set.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.cfg.Apply(set); err != nil {
+				t.Fatalf("Config.Apply() error = %v", err)
+			}
+			if got := ConfigOf(set); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConfigOf() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package life
+
+func (g *game) set(x, y int, alive bool) {
+	if g.s.inside(x, y) && g.s.alive(x, y) != alive {
+		g.record(x, y)
+		g.s.cycleCalc(x, y, alive)
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Set the cell alive or dead.
+func (g *game) Set(x, y int, alive bool) {
+	g.edit(func() {
+		g.set(x, y, alive)
+	})
+}
+
+// Line between x0 y0 and x1 y1.
+func (g *game) Line(x0, y0, x1, y1 int, alive bool) {
+	g.edit(func() {
+		dx, dy := abs(x1-x0), -abs(y1-y0)
+		sx, sy := 1, 1
+		if x0 > x1 {
+			sx = -1
+		}
+		if y0 > y1 {
+			sy = -1
+		}
+		e := dx + dy
+		for {
+			g.set(x0, y0, alive)
+			if x0 == x1 && y0 == y1 {
+				return
+			}
+			e2 := 2 * e
+			if e2 >= dy {
+				e += dy
+				x0 += sx
+			}
+			if e2 <= dx {
+				e += dx
+				y0 += sy
+			}
+		}
+	})
+}
+
+// Rect between corners x0 y0 and x1 y1, outlined or filled.
+func (g *game) Rect(x0, y0, x1, y1 int, alive, fill bool) {
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	if y0 > y1 {
+		y0, y1 = y1, y0
+	}
+	g.edit(func() {
+		for y := y0; y <= y1; y++ {
+			for x := x0; x <= x1; x++ {
+				if fill || x == x0 || x == x1 || y == y0 || y == y1 {
+					g.set(x, y, alive)
+				}
+			}
+		}
+	})
+}
+
+// Fill the region around x y whose cells are alike, up to its edges.
+func (g *game) Fill(x, y int, alive bool) {
+	if !g.s.inside(x, y) {
+		return
+	}
+	like := g.s.alive(x, y)
+	if like == alive {
+		return
+	}
+	g.edit(func() {
+		stack := []point{{x: x, y: y}}
+		for len(stack) > 0 {
+			p := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !g.s.inside(p.x, p.y) || g.s.alive(p.x, p.y) != like {
+				continue
+			}
+			g.set(p.x, p.y, alive)
+			stack = append(stack,
+				point{x: p.x + 1, y: p.y},
+				point{x: p.x - 1, y: p.y},
+				point{x: p.x, y: p.y + 1},
+				point{x: p.x, y: p.y - 1},
+			)
+		}
+	})
+}
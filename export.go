@@ -0,0 +1,115 @@
+package life
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"math"
+	"time"
+)
+
+// Export options of the image frames.
+type Export struct {
+	// Generations to run after the current one.
+	Generations int
+	// Cell size in pixels.
+	Cell int
+	// Grid draws lines between the cells.
+	Grid bool
+	// Delay between the animation frames.
+	Delay time.Duration
+}
+
+func (e Export) validate() error {
+	if e.Generations < 0 {
+		return fmt.Errorf("export: negative generations %d", e.Generations)
+	}
+	if e.Cell < 1 {
+		return fmt.Errorf("export: cell size %d must be positive", e.Cell)
+	}
+	// GIF delays are hundredths of a second in 16 bits.
+	if e.Delay < 0 || e.Delay/(10*time.Millisecond) > math.MaxUint16 {
+		return fmt.Errorf("export: delay %v is out of range", e.Delay)
+	}
+	return nil
+}
+
+// palette of every color the current theme may render.
+func (a *App) palette() (color.Palette, map[RGB]uint8) {
+	t := a.Theme.theme()
+	colors := []RGB{t.background, a.Theme.Grid()}
+	colors = append(colors, t.alive...)
+	colors = append(colors, t.dead...)
+	p := color.Palette{}
+	index := map[RGB]uint8{}
+	for _, c := range colors {
+		if _, ok := index[c]; !ok && len(p) < 256 {
+			index[c] = uint8(len(p))
+			p = append(p, c.RGBA())
+		}
+	}
+	return p, index
+}
+
+func (a *App) frame(s state, e Export, p color.Palette, index map[RGB]uint8) *image.Paletted {
+	grid := 0
+	if e.Grid {
+		grid = 1
+	}
+	img := image.NewPaletted(image.Rect(0, 0, s.width()*e.Cell+grid, s.height()*e.Cell+grid), p)
+	if e.Grid {
+		for i := range img.Pix {
+			img.Pix[i] = index[a.Theme.Grid()]
+		}
+	}
+	for y := range s {
+		for x, cycle := range s[y] {
+			c := index[a.Theme.Color(cycle)]
+			for py := grid; py < e.Cell; py++ {
+				for px := grid; px < e.Cell; px++ {
+					img.SetColorIndex(x*e.Cell+px, y*e.Cell+py, c)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// Frames runs a copy of the game and calls fn with every generation image,
+// starting from the current one.
+func (a *App) Frames(e Export, fn func(generation int, img *image.Paletted) error) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	p, index := a.palette()
+	g := a.Game.clone()
+	for i := 0; i <= e.Generations; i++ {
+		if i > 0 {
+			g.Step()
+		}
+		if err := fn(i, a.frame(g.s, e, p, index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteGIF animation of the next generations.
+func (a *App) WriteGIF(w io.Writer, e Export) error {
+	anim := &gif.GIF{}
+	delay := int(e.Delay / (10 * time.Millisecond))
+	err := a.Frames(e, func(_ int, img *image.Paletted) error {
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, delay)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(w, anim); err != nil {
+		return fmt.Errorf("export gif: %w", err)
+	}
+	return nil
+}
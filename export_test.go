@@ -0,0 +1,152 @@
+package life
+
+import (
+	"bytes"
+	"image/gif"
+	"testing"
+	"time"
+)
+
+func Test_App_frame(t *testing.T) {
+	tests := []struct {
+		name      string
+		e         Export
+		wantW     int
+		wantH     int
+		wantGrid  []point
+		wantAlive []point
+		wantBack  []point
+	}{
+		{
+			name:      "cells",
+			e:         Export{Cell: 2},
+			wantW:     6,
+			wantH:     4,
+			wantAlive: []point{{2, 0}, {3, 1}},
+			wantBack:  []point{{0, 0}, {5, 3}},
+		},
+		{
+			name:      "grid",
+			e:         Export{Cell: 3, Grid: true},
+			wantW:     10,
+			wantH:     7,
+			wantGrid:  []point{{0, 0}, {3, 1}, {4, 3}, {9, 6}},
+			wantAlive: []point{{4, 1}, {5, 2}},
+			wantBack:  []point{{1, 1}, {8, 5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewApp(3, 2, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			a.Theme.Set("whiteAndBlack")
+			a.Game.Set(1, 0, true)
+			p, index := a.palette()
+			img := a.frame(a.Game.s, tt.e, p, index)
+			if w, h := img.Bounds().Dx(), img.Bounds().Dy(); w != tt.wantW || h != tt.wantH {
+				t.Errorf("frame size = %vx%v, want %vx%v", w, h, tt.wantW, tt.wantH)
+			}
+			check := func(name string, points []point, c RGB) {
+				for _, pt := range points {
+					if got := img.ColorIndexAt(pt.x, pt.y); got != index[c] {
+						t.Errorf("%s pixel %v,%v = %v, want %v", name, pt.x, pt.y, got, index[c])
+					}
+				}
+			}
+			check("grid", tt.wantGrid, a.Theme.Grid())
+			check("alive", tt.wantAlive, a.Theme.Color(1))
+			check("background", tt.wantBack, a.Theme.Background())
+		})
+	}
+}
+
+func Test_App_palette(t *testing.T) {
+	a, err := NewApp(3, 3, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Theme.Set("ocean")
+	p, index := a.palette()
+	if len(p) != len(index) {
+		t.Fatalf("palette has %v colors, index %v", len(p), len(index))
+	}
+	for _, cycle := range []int{-100, -1, 0, 1, 5, 100} {
+		c := a.Theme.Color(cycle)
+		i, ok := index[c]
+		if !ok || p[i] != c.RGBA() {
+			t.Errorf("palette color of cycle %v = %v, %v, want %v", cycle, p[i], ok, c.RGBA())
+		}
+	}
+}
+
+func Test_App_WriteGIF(t *testing.T) {
+	tests := []struct {
+		name       string
+		e          Export
+		wantFrames int
+		wantDelay  int
+		wantErr    bool
+	}{
+		{
+			name:       "frames",
+			e:          Export{Generations: 3, Cell: 2, Delay: 50 * time.Millisecond},
+			wantFrames: 4,
+			wantDelay:  5,
+		},
+		{
+			name:       "current only",
+			e:          Export{Cell: 1},
+			wantFrames: 1,
+			wantDelay:  0,
+		},
+		{
+			name:    "negative generations",
+			e:       Export{Generations: -1, Cell: 1},
+			wantErr: true,
+		},
+		{
+			name:    "no cell size",
+			e:       Export{Generations: 1},
+			wantErr: true,
+		},
+		{
+			name:    "delay out of range",
+			e:       Export{Generations: 1, Cell: 1, Delay: 14 * time.Hour},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewApp(5, 5, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			a.Game.SetState(1, 2, [][]int{{1, 1, 1}})
+			var b bytes.Buffer
+			err = a.WriteGIF(&b, tt.e)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("App.WriteGIF() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			anim, err := gif.DecodeAll(&b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(anim.Image) != tt.wantFrames {
+				t.Errorf("frames = %v, want %v", len(anim.Image), tt.wantFrames)
+			}
+			for i, d := range anim.Delay {
+				if d != tt.wantDelay {
+					t.Errorf("frame %v delay = %v, want %v", i, d, tt.wantDelay)
+				}
+			}
+			if a.Game.Cycle() != 0 {
+				t.Errorf("game.Cycle() = %v, want the game left at %v", a.Game.Cycle(), 0)
+			}
+		})
+	}
+}
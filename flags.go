@@ -0,0 +1,101 @@
+package life
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Flags of the command line shared by the front-ends, set by Parse.
+type Flags struct {
+	set *flag.FlagSet
+
+	Width, Height int
+	// File of the pattern, "-" reads standard input.
+	File string
+	// Rec is the asciinema cast file to record to.
+	Rec string
+	// Colors is the color mode by name.
+	Colors string
+
+	period      time.Duration
+	threshold   uint
+	scale       float64
+	invert      bool
+	rewind      int
+	presets     string
+	theme       string
+	preset      string
+	config      string
+	printConfig bool
+	keys        map[string][]string
+}
+
+// NewFlags defines the flags on the set, the board is w by h by default.
+func NewFlags(set *flag.FlagSet, w, h int) *Flags {
+	f := &Flags{set: set}
+	set.IntVar(&f.Width, "w", w, "board width")
+	set.IntVar(&f.Height, "h", h, "board height")
+	set.StringVar(&f.File, "f", "", "pattern filename, \"-\" reads standard input")
+	set.DurationVar(&f.period, "d", 100*time.Millisecond, "screen refresh period")
+	set.UintVar(&f.threshold, "threshold", 128, "image pattern brightness below which a pixel is alive")
+	set.Float64Var(&f.scale, "scale", 1, "image pattern scale")
+	set.BoolVar(&f.invert, "invert", false, "image pattern light pixels are alive")
+	set.IntVar(&f.rewind, "rewind", rewindLimit, "number of generations kept to step back")
+	set.StringVar(&f.Rec, "rec", "", "record the session to an asciinema cast file")
+	set.StringVar(&f.Colors, "colors", "auto", "color mode: auto, true, 256, 16 or mono")
+	set.StringVar(&f.presets, "presets", "", fmt.Sprintf("directories of user presets, separated by %q", os.PathListSeparator))
+	set.StringVar(&f.theme, "theme", "", "starting theme name")
+	set.StringVar(&f.preset, "preset", "", "starting preset name")
+	set.StringVar(&f.config, "config", ConfigPath(), "config file, the flags take precedence over it")
+	set.BoolVar(&f.printConfig, "print-config", false, "print the effective configuration and exit")
+	return f
+}
+
+// Parse the arguments, then the config file for the flags they left out.
+// It prints the effective config for -print-config and reports that there
+// is nothing left to run.
+func (f *Flags) Parse(args []string) (bool, error) {
+	if err := f.set.Parse(args); err != nil {
+		return false, err
+	}
+	given := false
+	f.set.Visit(func(fl *flag.Flag) {
+		given = given || fl.Name == "config"
+	})
+	// A missing config file is fine unless it was asked for.
+	cfg, err := LoadConfig(f.config)
+	if err != nil && (given || !errors.Is(err, fs.ErrNotExist)) {
+		return false, err
+	}
+	if err := cfg.Apply(f.set); err != nil {
+		return false, err
+	}
+	f.keys = cfg.Keys
+	if f.printConfig {
+		c := ConfigOf(f.set)
+		c.Keys = cfg.Keys
+		return false, c.Write(os.Stdout)
+	}
+	if f.threshold > 255 {
+		return false, fmt.Errorf("threshold %d is over 255", f.threshold)
+	}
+	return true, nil
+}
+
+// Options of NewApp by the flags.
+func (f *Flags) Options() []Option {
+	return []Option{
+		WithImage(uint8(f.threshold), f.scale, f.invert),
+		WithRewind(f.rewind),
+		WithPeriod(f.period),
+		WithPresetDirs(filepath.SplitList(f.presets)...),
+		WithTheme(f.theme),
+		WithPreset(f.preset),
+		WithKeys(f.keys),
+	}
+}
@@ -0,0 +1,48 @@
+package life
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Flags_Parse(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	found := filepath.Join(dir, "found.json")
+	if err := os.WriteFile(found, []byte(`{"width": 80}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    int
+		run     bool
+		wantErr bool
+	}{
+		{name: "default config missing", want: 40, run: true},
+		{name: "default config asked for", args: []string{"-config", ConfigPath()}, wantErr: true},
+		{name: "config", args: []string{"-config", found}, want: 80, run: true},
+		{name: "flags take precedence", args: []string{"-config", found, "-w", "60"}, want: 60, run: true},
+		{name: "threshold over 255", args: []string{"-threshold", "256"}, want: 40, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			set.SetOutput(io.Discard)
+			f := NewFlags(set, 40, 23)
+			run, err := f.Parse(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Flags.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if run != tt.run {
+				t.Errorf("Flags.Parse() = %v, want %v", run, tt.run)
+			}
+			if err == nil && f.Width != tt.want {
+				t.Errorf("Flags.Width = %d, want %d", f.Width, tt.want)
+			}
+		})
+	}
+}
@@ -3,36 +3,82 @@ package life
 import "math/rand"
 
 type game struct {
-	s state
+	s     state
+	cycle int
+	h     history
+	r     rewind
+	heat  heat
 }
 
 func newGame(w, h int) *game {
 	return &game{
 		s: newState(w, h),
+		r: newRewind(rewindLimit),
 	}
 }
 
+func (g *game) clone() *game {
+	c := &game{
+		s:     newState(g.Width(), g.Height()),
+		cycle: g.cycle,
+	}
+	for y := range g.s {
+		copy(c.s[y], g.s[y])
+	}
+	return c
+}
+
 // Clear state.
 func (g *game) Clear() {
+	g.edit(func() {
+		g.clear()
+		g.cycle = 0
+	})
+	g.ResetHeat()
+}
+
+func (g *game) clear() {
+	for y := range g.s {
+		for x := range g.s[y] {
+			if g.s[y][x] != 0 {
+				g.record(x, y)
+			}
+		}
+	}
 	g.s = newState(g.s.width(), g.s.height())
 }
 
 // Random fills no more than a quarter of the state.
 func (g *game) Random() {
-	w := g.s.width()
-	h := g.s.height()
-	s := newState(w, h)
-	for i := 0; i < w*h/4; i++ {
-		s.init(rand.Intn(w), rand.Intn(h), true)
-	}
-	g.s = s
+	g.edit(func() {
+		w := g.s.width()
+		h := g.s.height()
+		g.clear()
+		for i := 0; i < w*h/4; i++ {
+			x, y := rand.Intn(w), rand.Intn(h)
+			g.record(x, y)
+			g.s.init(x, y, true)
+		}
+		g.cycle = 0
+	})
+	g.ResetHeat()
 }
 
 // Resize state
 func (g *game) Resize(w, h int) {
+	g.edit(func() {
+		g.resize(w, h)
+	})
+	g.r.reset()
+}
+
+func (g *game) resize(w, h int) {
 	s := newState(w, h)
 	for y := range g.s {
 		for x, count := range g.s[y] {
+			if !s.inside(x, y) && count != 0 {
+				g.record(x, y)
+			}
 			s.setCycle(x, y, count)
 		}
 	}
@@ -41,16 +87,67 @@ func (g *game) Resize(w, h int) {
 
 // SetState to the origin x y.
 func (g *game) SetState(x, y int, s [][]int) {
-	for yy := range s {
-		for xx := range s[yy] {
-			g.s.init(x+xx, y+yy, state(s).alive(xx, yy))
+	g.edit(func() {
+		for yy := range s {
+			for xx := range s[yy] {
+				if state(s).alive(xx, yy) {
+					g.record(x+xx, y+yy)
+					g.s.init(x+xx, y+yy, true)
+				}
+			}
+		}
+	})
+}
+
+// rect orders the corners and clips them to the state.
+func (g *game) rect(x0, y0, x1, y1 int) (int, int, int, int) {
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	if y0 > y1 {
+		y0, y1 = y1, y0
+	}
+	return max(x0, 0), max(y0, 0), min(x1, g.Width()-1), min(y1, g.Height()-1)
+}
+
+// Copy the alive cells of the rectangle between corners x0 y0 and x1 y1.
+func (g *game) Copy(x0, y0, x1, y1 int) [][]int {
+	x0, y0, x1, y1 = g.rect(x0, y0, x1, y1)
+	s := [][]int{}
+	for y := y0; y <= y1; y++ {
+		row := make([]int, 0, x1-x0+1)
+		for x := x0; x <= x1; x++ {
+			v := 0
+			if g.s.alive(x, y) {
+				v = 1
+			}
+			row = append(row, v)
 		}
+		s = append(s, row)
 	}
+	return s
+}
+
+// ClearRect between corners x0 y0 and x1 y1.
+func (g *game) ClearRect(x0, y0, x1, y1 int) {
+	x0, y0, x1, y1 = g.rect(x0, y0, x1, y1)
+	g.edit(func() {
+		for y := y0; y <= y1; y++ {
+			for x := x0; x <= x1; x++ {
+				g.record(x, y)
+				g.s.setCycle(x, y, 0)
+			}
+		}
+	})
 }
 
 // Shift cell state.
 func (g *game) Shift(x, y int) {
-	g.s.cycleCalc(x, y, !g.s.alive(x, y))
+	g.edit(func() {
+		x, y := g.s.boundless(x, y)
+		g.record(x, y)
+		g.s.cycleCalc(x, y, !g.s.alive(x, y))
+	})
 }
 
 // State return.
@@ -58,16 +155,29 @@ func (g *game) State() [][]int {
 	return g.s
 }
 
+// Cycle is the generation counter.
+func (g *game) Cycle() int {
+	return g.cycle
+}
+
 // Step to the next state.
 func (g *game) Step() {
+	g.Commit()
+	g.stepped()
+	g.r.push(compress(g.s, g.cycle))
+	heat := g.Heat()
 	s := newState(g.s.width(), g.s.height())
 	for y := range g.s {
 		for x := range g.s[y] {
+			if g.s.alive(x, y) {
+				heat[y][x]++
+			}
 			s.setCycle(x, y, g.s.cycle(x, y))
 			s.cycleCalc(x, y, g.s.next(x, y))
 		}
 	}
 	g.s = s
+	g.cycle++
 }
 
 // Height state return.
@@ -0,0 +1,500 @@
+package life
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_game_Undo(t *testing.T) {
+	stone := [][]int{
+		{1, 1},
+		{1, 1},
+	}
+	tests := []struct {
+		name  string
+		edits func(g *game)
+		undo  int
+		redo  int
+		want  [][]int
+	}{
+		{
+			name: "shift",
+			edits: func(g *game) {
+				g.Shift(0, 0)
+				g.Shift(1, 1)
+			},
+			undo: 1,
+			want: [][]int{
+				{1, 0, 0},
+				{0, 0, 0},
+			},
+		},
+		{
+			name: "clear",
+			edits: func(g *game) {
+				g.SetState(0, 0, stone)
+				g.Clear()
+			},
+			undo: 1,
+			want: [][]int{
+				{1, 1, 0},
+				{1, 1, 0},
+			},
+		},
+		{
+			name: "redo",
+			edits: func(g *game) {
+				g.SetState(1, 0, stone)
+				g.Clear()
+			},
+			undo: 2,
+			redo: 1,
+			want: [][]int{
+				{0, 1, 1},
+				{0, 1, 1},
+			},
+		},
+		{
+			name: "resize",
+			edits: func(g *game) {
+				g.SetState(1, 0, stone)
+				g.Resize(1, 1)
+			},
+			undo: 1,
+			want: [][]int{
+				{0, 1, 1},
+				{0, 1, 1},
+			},
+		},
+		{
+			name: "transaction",
+			edits: func(g *game) {
+				g.Begin()
+				g.Shift(0, 0)
+				g.Shift(1, 0)
+				g.Commit()
+			},
+			undo: 1,
+			want: [][]int{
+				{0, 0, 0},
+				{0, 0, 0},
+			},
+		},
+		{
+			name:  "empty",
+			edits: func(g *game) {},
+			undo:  1,
+			want: [][]int{
+				{0, 0, 0},
+				{0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGame(3, 2)
+			tt.edits(g)
+			for i := 0; i < tt.undo; i++ {
+				g.Undo()
+			}
+			for i := 0; i < tt.redo; i++ {
+				g.Redo()
+			}
+			if got := g.State(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("game.State() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_game_Undo_step(t *testing.T) {
+	g := newGame(5, 5)
+	g.SetState(1, 2, [][]int{{1, 1, 1}})
+	var before [][]int = g.clone().s
+	g.Shift(0, 0)
+	g.Step()
+	g.Step()
+	var after [][]int = g.clone().s
+	if !g.Undo() {
+		t.Errorf("game.Undo() = false after Step, want true")
+	}
+	if got := g.State(); !reflect.DeepEqual(got, before) {
+		t.Errorf("game.State() = %v, want %v", got, before)
+	}
+	if g.Cycle() != 0 {
+		t.Errorf("game.Cycle() = %v, want %v", g.Cycle(), 0)
+	}
+	if g.Undo() {
+		t.Errorf("game.Undo() = true, want only the last edit kept")
+	}
+	g.Redo()
+	if got := g.State(); !reflect.DeepEqual(got, after) {
+		t.Errorf("game.State() = %v, want %v", got, after)
+	}
+	if g.Cycle() != 2 {
+		t.Errorf("game.Cycle() = %v, want %v", g.Cycle(), 2)
+	}
+}
+
+func Test_game_Editing(t *testing.T) {
+	g := newGame(5, 5)
+	g.Begin()
+	g.Set(2, 2, true)
+	if !g.Editing() {
+		t.Errorf("game.Editing() = false after Begin, want true")
+	}
+	g.Step()
+	if g.Editing() {
+		t.Errorf("game.Editing() = true after Step, want false")
+	}
+	g.Commit()
+	if !g.Undo() {
+		t.Errorf("game.Undo() = false, want the edit committed by Step")
+	}
+	if got, want := g.State(), newGame(5, 5).State(); !reflect.DeepEqual(got, want) {
+		t.Errorf("game.State() = %v, want %v", got, want)
+	}
+}
+
+func Test_game_Undo_back(t *testing.T) {
+	g := newGame(5, 5)
+	g.Step()
+	g.Shift(2, 2)
+	g.Back()
+	var want [][]int = g.clone().s
+	if g.Undo() {
+		t.Errorf("game.Undo() = true after Back, want false")
+	}
+	if got := g.State(); !reflect.DeepEqual(got, want) {
+		t.Errorf("game.State() = %v, want %v", got, want)
+	}
+	if g.Cycle() != 0 {
+		t.Errorf("game.Cycle() = %v, want %v", g.Cycle(), 0)
+	}
+}
+
+func Test_game_Back(t *testing.T) {
+	blinker := [][]int{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	tests := []struct {
+		name      string
+		limit     int
+		steps     int
+		back      int
+		wantOk    bool
+		wantCycle int
+	}{
+		{
+			name:      "one",
+			limit:     10,
+			steps:     3,
+			back:      1,
+			wantOk:    true,
+			wantCycle: 2,
+		},
+		{
+			name:      "all",
+			limit:     10,
+			steps:     3,
+			back:      3,
+			wantOk:    true,
+			wantCycle: 0,
+		},
+		{
+			name:      "over the limit",
+			limit:     2,
+			steps:     5,
+			back:      3,
+			wantOk:    false,
+			wantCycle: 3,
+		},
+		{
+			name:      "disabled",
+			limit:     0,
+			steps:     1,
+			back:      1,
+			wantOk:    false,
+			wantCycle: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGame(5, 5)
+			g.r = newRewind(tt.limit)
+			g.SetState(0, 0, blinker)
+			want := [][][]int{}
+			for i := 0; i < tt.steps; i++ {
+				want = append(want, g.clone().s)
+				g.Step()
+			}
+			ok := true
+			for i := 0; i < tt.back; i++ {
+				ok = g.Back()
+			}
+			if ok != tt.wantOk {
+				t.Errorf("game.Back() = %v, want %v", ok, tt.wantOk)
+			}
+			if g.Cycle() != tt.wantCycle {
+				t.Errorf("game.Cycle() = %v, want %v", g.Cycle(), tt.wantCycle)
+			}
+			if got := g.State(); tt.wantCycle < tt.steps && !reflect.DeepEqual(got, want[tt.wantCycle]) {
+				t.Errorf("game.State() = %v, want %v", got, want[tt.wantCycle])
+			}
+		})
+	}
+}
+
+func Test_game_StepTo(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		start     int
+		target    int
+		wantDone  bool
+		wantCycle int
+	}{
+		{
+			name:      "forward",
+			limit:     10,
+			start:     0,
+			target:    7,
+			wantDone:  true,
+			wantCycle: 7,
+		},
+		{
+			name:      "back",
+			limit:     10,
+			start:     7,
+			target:    3,
+			wantDone:  true,
+			wantCycle: 3,
+		},
+		{
+			name:      "back over the limit",
+			limit:     2,
+			start:     7,
+			target:    3,
+			wantDone:  true,
+			wantCycle: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGame(5, 5)
+			g.r = newRewind(tt.limit)
+			for i := 0; i < tt.start; i++ {
+				g.Step()
+			}
+			_, done := g.StepTo(tt.target, time.Now().Add(time.Minute))
+			if done != tt.wantDone {
+				t.Errorf("game.StepTo() done = %v, want %v", done, tt.wantDone)
+			}
+			if g.Cycle() != tt.wantCycle {
+				t.Errorf("game.Cycle() = %v, want %v", g.Cycle(), tt.wantCycle)
+			}
+		})
+	}
+}
+
+func Test_game_draw(t *testing.T) {
+	tests := []struct {
+		name string
+		draw func(g *game)
+		want [][]int
+	}{
+		{
+			name: "line",
+			draw: func(g *game) {
+				g.Line(0, 0, 3, 2, true)
+			},
+			want: [][]int{
+				{1, 0, 0, 0},
+				{0, 1, 1, 0},
+				{0, 0, 0, 1},
+			},
+		},
+		{
+			name: "rect",
+			draw: func(g *game) {
+				g.Rect(3, 2, 0, 0, true, false)
+			},
+			want: [][]int{
+				{1, 1, 1, 1},
+				{1, 0, 0, 1},
+				{1, 1, 1, 1},
+			},
+		},
+		{
+			name: "fill",
+			draw: func(g *game) {
+				g.Line(2, 0, 2, 2, true)
+				g.Fill(0, 1, true)
+			},
+			want: [][]int{
+				{1, 1, 1, 0},
+				{1, 1, 1, 0},
+				{1, 1, 1, 0},
+			},
+		},
+		{
+			name: "erase",
+			draw: func(g *game) {
+				g.Rect(0, 0, 3, 2, true, true)
+				g.Set(1, 1, false)
+			},
+			want: [][]int{
+				{1, 1, 1, 1},
+				{1, -1, 1, 1},
+				{1, 1, 1, 1},
+			},
+		},
+		{
+			name: "revert",
+			draw: func(g *game) {
+				g.Begin()
+				g.Line(0, 0, 3, 0, true)
+				g.Revert()
+				g.Line(0, 2, 3, 2, true)
+				g.Commit()
+			},
+			want: [][]int{
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+				{1, 1, 1, 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGame(4, 3)
+			tt.draw(g)
+			if got := g.State(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("game.State() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_game_Center(t *testing.T) {
+	tests := []struct {
+		name   string
+		alive  []point
+		wantX  float64
+		wantY  float64
+		wantOk bool
+	}{
+		{
+			name:   "stone",
+			alive:  []point{{2, 4}, {3, 4}, {2, 5}, {3, 5}},
+			wantX:  2.5,
+			wantY:  4.5,
+			wantOk: true,
+		},
+		{
+			name:   "across the edge",
+			alive:  []point{{9, 0}, {0, 0}},
+			wantX:  9.5,
+			wantY:  0,
+			wantOk: true,
+		},
+		{
+			name:   "empty",
+			alive:  []point{},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGame(10, 10)
+			for _, p := range tt.alive {
+				g.Set(p.x, p.y, true)
+			}
+			x, y, ok := g.Center()
+			if ok != tt.wantOk || math.Abs(x-tt.wantX) > 1e-9 || math.Abs(y-tt.wantY) > 1e-9 {
+				t.Errorf("game.Center() = %v, %v, %v, want %v, %v, %v", x, y, ok, tt.wantX, tt.wantY, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_game_Bounds(t *testing.T) {
+	tests := []struct {
+		name  string
+		alive []point
+		rect  [4]int
+		want  [4]int
+		ok    bool
+	}{
+		{
+			name:  "stone",
+			alive: []point{{2, 4}, {3, 4}, {2, 5}, {3, 5}},
+			rect:  [4]int{0, 0, 9, 9},
+			want:  [4]int{2, 4, 3, 5},
+			ok:    true,
+		},
+		{
+			name:  "across the edge",
+			alive: []point{{9, 0}, {0, 0}},
+			rect:  [4]int{5, -2, 12, 2},
+			want:  [4]int{9, 0, 10, 0},
+			ok:    true,
+		},
+		{
+			name:  "empty",
+			alive: []point{{2, 4}},
+			rect:  [4]int{5, 5, 9, 9},
+			ok:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGame(10, 10)
+			for _, p := range tt.alive {
+				g.Set(p.x, p.y, true)
+			}
+			x0, y0, x1, y1, ok := g.Bounds(tt.rect[0], tt.rect[1], tt.rect[2], tt.rect[3])
+			if got := [4]int{x0, y0, x1, y1}; ok != tt.ok || ok && got != tt.want {
+				t.Errorf("game.Bounds() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func Test_game_Heat(t *testing.T) {
+	g := newGame(5, 5)
+	g.SetState(0, 0, [][]int{
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 1, 1, 1, 0},
+	})
+	g.Step()
+	g.Step()
+	want := [][]int{
+		{0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0},
+		{0, 1, 2, 1, 0},
+		{0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 0},
+	}
+	if got := g.Heat(); !reflect.DeepEqual(got, want) {
+		t.Errorf("game.Heat() = %v, want %v", got, want)
+	}
+	if got := g.HeatMax(); got != 2 {
+		t.Errorf("game.HeatMax() = %v, want %v", got, 2)
+	}
+	g.Resize(3, 3)
+	if got := g.Heat(); len(got) != 3 || len(got[0]) != 3 || got[2][2] != 2 {
+		t.Errorf("game.Heat() after Resize = %v, want the 3x3 corner", got)
+	}
+	g.Clear()
+	if got := g.HeatMax(); got != 0 {
+		t.Errorf("game.HeatMax() after Clear = %v, want %v", got, 0)
+	}
+}
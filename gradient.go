@@ -0,0 +1,177 @@
+package life
+
+import (
+	"fmt"
+	"math"
+)
+
+// Color spaces a gradient is interpolated in.
+const (
+	SpaceOKLab = "oklab"
+	SpaceHSL   = "hsl"
+	SpaceRGB   = "rgb"
+)
+
+// lab is a color in OKLab.
+type lab struct{ l, a, b float64 }
+
+func linear(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func unlinear(v float64) uint8 {
+	if v <= 0.0031308 {
+		v *= 12.92
+	} else {
+		v = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(min(max(v, 0), 1) * 255))
+}
+
+func (c RGB) lab() lab {
+	r, g, b := linear(c.r), linear(c.g), linear(c.b)
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+	return lab{
+		l: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		a: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		b: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+func (c lab) rgb() RGB {
+	l := math.Pow(c.l+0.3963377774*c.a+0.2158037573*c.b, 3)
+	m := math.Pow(c.l-0.1055613458*c.a-0.0638541728*c.b, 3)
+	s := math.Pow(c.l-0.0894841775*c.a-1.2914855480*c.b, 3)
+	return NewRGB(
+		unlinear(4.0767416621*l-3.3077115913*m+0.2309699292*s),
+		unlinear(-1.2684380046*l+2.6097574011*m-0.3413193965*s),
+		unlinear(-0.0041960863*l-0.7034186147*m+1.7076147010*s),
+	)
+}
+
+// hsl is a color by hue in degrees, saturation and lightness.
+type hsl struct{ h, s, l float64 }
+
+func (c RGB) hsl() hsl {
+	r, g, b := float64(c.r)/255, float64(c.g)/255, float64(c.b)/255
+	hi, lo := max(r, g, b), min(r, g, b)
+	l := (hi + lo) / 2
+	d := hi - lo
+	if d == 0 {
+		return hsl{l: l}
+	}
+	s := d / (1 - math.Abs(2*l-1))
+	var h float64
+	switch hi {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return hsl{h: math.Mod(h*60+360, 360), s: s, l: l}
+}
+
+func (c hsl) rgb() RGB {
+	ch := (1 - math.Abs(2*c.l-1)) * c.s
+	x := ch * (1 - math.Abs(math.Mod(c.h/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case c.h < 60:
+		r, g = ch, x
+	case c.h < 120:
+		r, g = x, ch
+	case c.h < 180:
+		g, b = ch, x
+	case c.h < 240:
+		g, b = x, ch
+	case c.h < 300:
+		r, b = x, ch
+	default:
+		r, b = ch, x
+	}
+	m := c.l - ch/2
+	v := func(f float64) uint8 {
+		return uint8(math.Round(min(max(f+m, 0), 1) * 255))
+	}
+	return NewRGB(v(r), v(g), v(b))
+}
+
+// mixIn mixes colors a and b in the space, t is the share of b.
+func mixIn(a, b RGB, t float64, space string) RGB {
+	lerp := func(x, y float64) float64 {
+		return x + (y-x)*t
+	}
+	switch space {
+	case SpaceHSL:
+		p, q := a.hsl(), b.hsl()
+		// The hue turns the short way round, a gray keeps the other hue.
+		switch {
+		case p.s == 0:
+			p.h = q.h
+		case q.s == 0:
+			q.h = p.h
+		case q.h-p.h > 180:
+			p.h += 360
+		case p.h-q.h > 180:
+			q.h += 360
+		}
+		return hsl{
+			h: math.Mod(lerp(p.h, q.h), 360),
+			s: lerp(p.s, q.s),
+			l: lerp(p.l, q.l),
+		}.rgb()
+	case SpaceRGB:
+		return Mix(a, b, t)
+	default:
+		p, q := a.lab(), b.lab()
+		return lab{l: lerp(p.l, q.l), a: lerp(p.a, q.a), b: lerp(p.b, q.b)}.rgb()
+	}
+}
+
+// gradient of n colors from a to b, interpolated in the space.
+func gradient(a, b RGB, n int, space string) []RGB {
+	if n == 1 {
+		return []RGB{a}
+	}
+	c := make([]RGB, n)
+	for i := range c {
+		c[i] = mixIn(a, b, float64(i)/float64(n-1), space)
+	}
+	return c
+}
+
+// gradientFile is a gradient as written in a theme file.
+type gradientFile struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Length int    `json:"length"`
+	Space  string `json:"space"`
+}
+
+func (g gradientFile) colors() ([]RGB, error) {
+	switch g.Space {
+	case "", SpaceOKLab, SpaceHSL, SpaceRGB:
+	default:
+		return nil, fmt.Errorf("space %q is not %s, %s or %s", g.Space, SpaceOKLab, SpaceHSL, SpaceRGB)
+	}
+	if g.Length < 1 {
+		return nil, fmt.Errorf("length %d is less than 1", g.Length)
+	}
+	from, err := parseHex(g.From)
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	to, err := parseHex(g.To)
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+	return gradient(from, to, g.Length, g.Space), nil
+}
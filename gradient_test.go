@@ -0,0 +1,66 @@
+package life
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_gradient(t *testing.T) {
+	black, white := NewRGB(0, 0, 0), NewRGB(255, 255, 255)
+	red, blue := NewRGB(255, 0, 0), NewRGB(0, 0, 255)
+	tests := []struct {
+		name  string
+		a, b  RGB
+		n     int
+		space string
+		want  []RGB
+	}{
+		{
+			name:  "one",
+			a:     red,
+			b:     blue,
+			n:     1,
+			space: SpaceOKLab,
+			want:  []RGB{red},
+		},
+		{
+			name:  "rgb",
+			a:     black,
+			b:     white,
+			n:     3,
+			space: SpaceRGB,
+			want:  []RGB{black, NewRGB(128, 128, 128), white},
+		},
+		{
+			name:  "oklab keeps the ends",
+			a:     red,
+			b:     blue,
+			n:     2,
+			space: SpaceOKLab,
+			want:  []RGB{red, blue},
+		},
+		{
+			name:  "oklab gray is perceptual",
+			a:     black,
+			b:     white,
+			n:     3,
+			space: SpaceOKLab,
+			want:  []RGB{black, NewRGB(99, 99, 99), white},
+		},
+		{
+			name:  "hsl hue the short way",
+			a:     red,
+			b:     blue,
+			n:     3,
+			space: SpaceHSL,
+			want:  []RGB{red, NewRGB(255, 0, 255), blue},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gradient(tt.a, tt.b, tt.n, tt.space); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("gradient() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package life
+
+// heat counts the generations each cell has been alive.
+type heat [][]int
+
+// fit the heat to the size of the state, keeping the counts that fit.
+func (h heat) fit(w, ht int) heat {
+	if len(h) == ht && (ht == 0 || len(h[0]) == w) {
+		return h
+	}
+	n := make(heat, ht)
+	for y := range n {
+		n[y] = make([]int, w)
+		if y < len(h) {
+			copy(n[y], h[y])
+		}
+	}
+	return n
+}
+
+// Heat is the number of generations each cell has been alive, a cell that
+// has ever been is within the envelope of the pattern.
+func (g *game) Heat() [][]int {
+	g.heat = g.heat.fit(g.Width(), g.Height())
+	return g.heat
+}
+
+// HeatMax is the most generations a cell has been alive.
+func (g *game) HeatMax() int {
+	m := 0
+	for _, row := range g.Heat() {
+		for _, n := range row {
+			m = max(m, n)
+		}
+	}
+	return m
+}
+
+// ResetHeat forgets the activity so far.
+func (g *game) ResetHeat() {
+	g.heat = nil
+}
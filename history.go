@@ -0,0 +1,199 @@
+package life
+
+// historyLimit bounds the number of cell changes kept for undo and redo.
+const historyLimit = 1 << 20
+
+type change struct {
+	x, y     int
+	from, to int
+}
+
+// edit is the difference between two states, sizes included.
+type edit struct {
+	fromW, fromH       int
+	toW, toH           int
+	fromCycle, toCycle int
+	cells              []change
+}
+
+func diff(from, to *game) edit {
+	e := edit{
+		fromW:     from.Width(),
+		fromH:     from.Height(),
+		toW:       to.Width(),
+		toH:       to.Height(),
+		fromCycle: from.cycle,
+		toCycle:   to.cycle,
+	}
+	for y := 0; y < max(e.fromH, e.toH); y++ {
+		for x := 0; x < max(e.fromW, e.toW); x++ {
+			if f, t := from.s.cycle(x, y), to.s.cycle(x, y); f != t {
+				e.cells = append(e.cells, change{x: x, y: y, from: f, to: t})
+			}
+		}
+	}
+	return e
+}
+
+func (e edit) empty() bool {
+	return len(e.cells) == 0 && e.fromW == e.toW && e.fromH == e.toH && e.fromCycle == e.toCycle
+}
+
+type history struct {
+	undo []edit
+	redo []edit
+	// pending is the open edit, its cells hold the values from before it.
+	pending *edit
+	seen    map[point]bool
+	// base is the game before the last edit, once the board stepped since.
+	base *game
+}
+
+// push the edit for undo, the oldest edits are dropped over the limit.
+func (h *history) push(e edit) {
+	if e.empty() {
+		return
+	}
+	h.undo = append(h.undo, e)
+	h.redo = nil
+	cells := 0
+	for _, e := range h.undo {
+		cells += len(e.cells)
+	}
+	for cells > historyLimit && len(h.undo) > 1 {
+		cells -= len(h.undo[0].cells)
+		h.undo = h.undo[1:]
+	}
+}
+
+// record the cell at x y before the open edit writes it.
+func (g *game) record(x, y int) {
+	h := &g.h
+	p := point{x: x, y: y}
+	if h.pending == nil || h.seen[p] || !g.s.inside(x, y) {
+		return
+	}
+	h.seen[p] = true
+	c := g.s.cycle(x, y)
+	h.pending.cells = append(h.pending.cells, change{x: x, y: y, from: c, to: c})
+}
+
+// Begin an edit, the changes until Commit are undone together. Nested
+// calls join the outer edit and report false.
+func (g *game) Begin() bool {
+	if g.h.pending != nil {
+		return false
+	}
+	g.h.pending = &edit{fromW: g.Width(), fromH: g.Height(), fromCycle: g.cycle}
+	g.h.seen = map[point]bool{}
+	return true
+}
+
+// Commit the edit started by Begin.
+func (g *game) Commit() {
+	e := g.h.pending
+	if e == nil {
+		return
+	}
+	e.toW, e.toH, e.toCycle = g.Width(), g.Height(), g.cycle
+	cells := e.cells[:0]
+	for _, c := range e.cells {
+		c.to = g.s.cycle(c.x, c.y)
+		if c.from != c.to {
+			cells = append(cells, c)
+		}
+	}
+	e.cells = cells
+	g.h.pending, g.h.seen = nil, nil
+	g.h.push(*e)
+}
+
+// Editing reports whether an edit is open since Begin.
+func (g *game) Editing() bool {
+	return g.h.pending != nil
+}
+
+// Revert the changes since Begin, the edit stays open.
+func (g *game) Revert() {
+	e := g.h.pending
+	if e == nil {
+		return
+	}
+	g.h.pending = nil
+	g.apply(*e, false)
+	g.h.pending = e
+}
+
+// edit runs op as one undoable operation.
+func (g *game) edit(op func()) {
+	if !g.Begin() {
+		op()
+		return
+	}
+	op()
+	g.Commit()
+}
+
+// stepped keeps the last edit undoable once the board steps, by the game
+// from before it.
+func (g *game) stepped() {
+	h := &g.h
+	if len(h.undo) > 0 {
+		b := g.clone()
+		b.apply(h.undo[len(h.undo)-1], false)
+		h.base = b
+	}
+	h.undo, h.redo = nil, nil
+}
+
+// apply the edit forwards or backwards.
+func (g *game) apply(e edit, forward bool) {
+	w, h, cycle := e.fromW, e.fromH, e.fromCycle
+	if forward {
+		w, h, cycle = e.toW, e.toH, e.toCycle
+	}
+	g.cycle = cycle
+	if w != g.Width() || h != g.Height() {
+		g.resize(w, h)
+	}
+	for _, c := range e.cells {
+		v := c.from
+		if forward {
+			v = c.to
+		}
+		g.s.setCycle(c.x, c.y, v)
+	}
+}
+
+// Undo the last edit, reports whether there was one.
+func (g *game) Undo() bool {
+	h := &g.h
+	if len(h.undo) == 0 && h.base != nil {
+		e := diff(h.base, g)
+		h.redo = append(h.redo, e)
+		g.apply(e, false)
+		h.base = nil
+		return true
+	}
+	if len(h.undo) == 0 {
+		return false
+	}
+	e := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, e)
+	g.apply(e, false)
+	return true
+}
+
+// Redo the last undone edit, reports whether there was one.
+func (g *game) Redo() bool {
+	h := &g.h
+	if len(h.redo) == 0 {
+		return false
+	}
+	e := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, e)
+	g.apply(e, true)
+	return true
+}
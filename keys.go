@@ -0,0 +1,274 @@
+package life
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Action is a command of the front-ends that keys are bound to.
+type Action string
+
+const (
+	ActionQuit         Action = "quit"
+	ActionPause        Action = "pause"
+	ActionStep         Action = "step"
+	ActionBack         Action = "back"
+	ActionClear        Action = "clear"
+	ActionRandom       Action = "random"
+	ActionTheme        Action = "theme"
+	ActionPreset       Action = "preset"
+	ActionPresetNextIn Action = "preset-next-in"
+	ActionPresetPrevIn Action = "preset-prev-in"
+	ActionCategory     Action = "category"
+	ActionBrowse       Action = "browse"
+	ActionInsert       Action = "insert"
+	ActionInfo         Action = "info"
+	ActionUndo         Action = "undo"
+	ActionRedo         Action = "redo"
+	ActionFaster       Action = "faster"
+	ActionSlower       Action = "slower"
+	ActionStepN        Action = "step-n"
+	ActionGoto         Action = "goto"
+	ActionCursor       Action = "cursor"
+	ActionUp           Action = "up"
+	ActionDown         Action = "down"
+	ActionLeft         Action = "left"
+	ActionRight        Action = "right"
+	ActionToggle       Action = "toggle"
+	ActionSelect       Action = "select"
+	ActionCopy         Action = "copy"
+	ActionCut          Action = "cut"
+	ActionPaste        Action = "paste"
+	ActionDelete       Action = "delete"
+	ActionRotate       Action = "rotate"
+	ActionFlipH        Action = "flip-h"
+	ActionFlipV        Action = "flip-v"
+	ActionInk          Action = "ink"
+	ActionZoomIn       Action = "zoom-in"
+	ActionZoomOut      Action = "zoom-out"
+	ActionRender       Action = "render"
+	ActionOverlay      Action = "overlay"
+	ActionFollow       Action = "follow"
+	ActionFollowTarget Action = "follow-target"
+	ActionPanUp        Action = "pan-up"
+	ActionPanDown      Action = "pan-down"
+	ActionPanLeft      Action = "pan-left"
+	ActionPanRight     Action = "pan-right"
+	ActionToolToggle   Action = "tool-toggle"
+	ActionToolSelect   Action = "tool-select"
+	ActionToolPen      Action = "tool-pen"
+	ActionToolLine     Action = "tool-line"
+	ActionToolRect     Action = "tool-rect"
+	ActionToolBox      Action = "tool-box"
+	ActionToolFill     Action = "tool-fill"
+	ActionBrowsePrev   Action = "browse-prev"
+	ActionBrowseNext   Action = "browse-next"
+	ActionPageUp       Action = "browse-page-up"
+	ActionPageDown     Action = "browse-page-down"
+	ActionPick         Action = "browse-pick"
+	ActionClose        Action = "browse-close"
+)
+
+// mode of the front-end in which a binding holds.
+type mode uint8
+
+const (
+	modeNormal mode = 1 << iota
+	modeCursor
+	// modeBrowse holds in the preset browser, where the other keys type
+	// the search.
+	modeBrowse
+	modeBoth = modeNormal | modeCursor
+)
+
+type binding struct {
+	action Action
+	help   string
+	keys   []string
+	mode   mode
+}
+
+// keymap binds the keys to the actions. The keys are named as typed for
+// the printable ones, else Space, Enter, Tab, Esc, Backspace, Delete, Up,
+// Down, Left, Right, PgUp, PgDn or Ctrl+<letter>.
+type keymap struct {
+	store []binding
+}
+
+func newKeymap() *keymap {
+	return &keymap{
+		store: []binding{
+			{ActionQuit, "quit", []string{"q", "Esc", "Ctrl+C"}, modeNormal},
+			{ActionPause, "pause", []string{"Space"}, modeNormal},
+			{ActionStep, "next", []string{"Enter", "s"}, modeNormal},
+			{ActionBack, "back", []string{"b"}, modeNormal},
+			{ActionClear, "clear", []string{"c"}, modeNormal},
+			{ActionRandom, "random", []string{"r"}, modeNormal},
+			{ActionTheme, "switch theme", []string{"t"}, modeNormal},
+			{ActionPreset, "switch preset", []string{"p"}, modeNormal},
+			{ActionPresetNextIn, "next in category", []string{"]"}, modeNormal},
+			{ActionPresetPrevIn, "previous in category", []string{"["}, modeNormal},
+			{ActionCategory, "next category", []string{"}"}, modeNormal},
+			{ActionBrowse, "browse presets", []string{"L"}, modeNormal},
+			{ActionInsert, "insert preset", []string{"i"}, modeBoth},
+			{ActionInfo, "hide this message", []string{"h"}, modeNormal},
+			{ActionUndo, "undo", []string{"u", "Ctrl+Z"}, modeNormal},
+			{ActionRedo, "redo", []string{"U", "Ctrl+Y"}, modeNormal},
+			{ActionFaster, "faster", []string{">"}, modeNormal},
+			{ActionSlower, "slower", []string{"<"}, modeNormal},
+			{ActionStepN, "step generations", []string{"n"}, modeNormal},
+			{ActionGoto, "go to generation", []string{"G"}, modeNormal},
+			{ActionCursor, "cursor mode", []string{"Tab"}, modeBoth},
+			{ActionUp, "up", []string{"k", "Up"}, modeCursor},
+			{ActionDown, "down", []string{"j", "Down"}, modeCursor},
+			{ActionLeft, "left", []string{"h", "Left"}, modeCursor},
+			{ActionRight, "right", []string{"l", "Right"}, modeCursor},
+			{ActionToggle, "toggle", []string{"."}, modeCursor},
+			{ActionSelect, "select", []string{"v"}, modeNormal},
+			{ActionCopy, "copy", []string{"y"}, modeNormal},
+			{ActionCut, "cut", []string{"x"}, modeNormal},
+			{ActionPaste, "paste", []string{"P"}, modeNormal},
+			{ActionDelete, "clear selection", []string{"Delete", "Backspace"}, modeNormal},
+			{ActionRotate, "rotate stamp", []string{"o"}, modeNormal},
+			{ActionFlipH, "mirror left to right", []string{"m"}, modeNormal},
+			{ActionFlipV, "mirror top to bottom", []string{"M"}, modeNormal},
+			{ActionInk, "draw or erase", []string{"e"}, modeNormal},
+			{ActionZoomIn, "zoom in", []string{"+", "="}, modeNormal},
+			{ActionZoomOut, "zoom out", []string{"-"}, modeNormal},
+			{ActionRender, "render", []string{"g"}, modeNormal},
+			{ActionOverlay, "overlay", []string{"H"}, modeNormal},
+			{ActionFollow, "follow", []string{"f"}, modeNormal},
+			{ActionFollowTarget, "centroid or bounds", []string{"F"}, modeNormal},
+			{ActionPanUp, "pan up", []string{"Up"}, modeNormal},
+			{ActionPanDown, "pan down", []string{"Down"}, modeNormal},
+			{ActionPanLeft, "pan left", []string{"Left"}, modeNormal},
+			{ActionPanRight, "pan right", []string{"Right"}, modeNormal},
+			{ActionToolToggle, "toggle", []string{"1"}, modeNormal},
+			{ActionToolSelect, "select", []string{"2"}, modeNormal},
+			{ActionToolPen, "pen", []string{"3"}, modeNormal},
+			{ActionToolLine, "line", []string{"4"}, modeNormal},
+			{ActionToolRect, "rectangle", []string{"5"}, modeNormal},
+			{ActionToolBox, "filled rectangle", []string{"6"}, modeNormal},
+			{ActionToolFill, "flood fill", []string{"7"}, modeNormal},
+			{ActionBrowsePrev, "previous", []string{"Up", "Ctrl+P"}, modeBrowse},
+			{ActionBrowseNext, "next", []string{"Down", "Ctrl+N"}, modeBrowse},
+			{ActionPageUp, "page up", []string{"PgUp"}, modeBrowse},
+			{ActionPageDown, "page down", []string{"PgDn"}, modeBrowse},
+			{ActionPick, "pick", []string{"Enter"}, modeBrowse},
+			{ActionClose, "close", []string{"Esc", "Ctrl+C"}, modeBrowse},
+		},
+	}
+}
+
+// KeyName of the typed rune.
+func KeyName(r rune) string {
+	switch r {
+	case ' ':
+		return "Space"
+	case '\t':
+		return "Tab"
+	case '\r', '\n':
+		return "Enter"
+	case '\x1b':
+		return "Esc"
+	case '\b', '\x7f':
+		return "Backspace"
+	}
+	return string(r)
+}
+
+func (k *keymap) find(key string, m mode) (Action, bool) {
+	for _, b := range k.store {
+		if b.mode&m == 0 {
+			continue
+		}
+		if slices.Contains(b.keys, key) {
+			return b.action, true
+		}
+	}
+	return "", false
+}
+
+// Action bound to the key.
+func (k *keymap) Action(key string) (Action, bool) {
+	return k.find(key, modeNormal)
+}
+
+// Cursor is the action bound to the key in cursor mode, it takes over the
+// one of Action.
+func (k *keymap) Cursor(key string) (Action, bool) {
+	return k.find(key, modeCursor)
+}
+
+// Browse is the action bound to the key in the preset browser.
+func (k *keymap) Browse(key string) (Action, bool) {
+	return k.find(key, modeBrowse)
+}
+
+func (k *keymap) binding(a Action) *binding {
+	for i := range k.store {
+		if k.store[i].action == a {
+			return &k.store[i]
+		}
+	}
+	return nil
+}
+
+// Key is the first key of the action, empty if it has none.
+func (k *keymap) Key(a Action) string {
+	if b := k.binding(a); b != nil && len(b.keys) > 0 {
+		return b.keys[0]
+	}
+	return ""
+}
+
+// Join the first keys of the actions with slashes.
+func (k *keymap) Join(actions ...Action) string {
+	keys := make([]string, len(actions))
+	for i, a := range actions {
+		keys[i] = k.Key(a)
+	}
+	return strings.Join(keys, "/")
+}
+
+// Help lists the actions, format takes the first key and the description
+// of each. The actions without keys are left out.
+func (k *keymap) Help(format string, actions ...Action) string {
+	var help []string
+	for _, a := range actions {
+		if b := k.binding(a); b != nil && len(b.keys) > 0 {
+			help = append(help, fmt.Sprintf(format, b.keys[0], b.help))
+		}
+	}
+	return strings.Join(help, ", ")
+}
+
+// bind the action to the keys in place of its own, they are taken from
+// the actions of the same mode.
+func (k *keymap) bind(a Action, keys []string) error {
+	b := k.binding(a)
+	if b == nil {
+		return fmt.Errorf("action %q is unknown", a)
+	}
+	for _, key := range keys {
+		if key == "" {
+			return fmt.Errorf("action %q has an empty key", a)
+		}
+	}
+	for i := range k.store {
+		o := &k.store[i]
+		if o == b || o.mode&b.mode == 0 {
+			continue
+		}
+		kept := o.keys[:0:0]
+		for _, key := range o.keys {
+			if !slices.Contains(keys, key) {
+				kept = append(kept, key)
+			}
+		}
+		o.keys = kept
+	}
+	b.keys = append([]string{}, keys...)
+	return nil
+}
@@ -0,0 +1,102 @@
+package life
+
+import (
+	"testing"
+)
+
+func Test_keymap_bind(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     Action
+		keys       []string
+		key        string
+		cursor     bool
+		wantAction Action
+		wantOk     bool
+		wantErr    bool
+	}{
+		{
+			name:       "default",
+			key:        "Enter",
+			wantAction: ActionStep,
+			wantOk:     true,
+		},
+		{
+			name:       "cursor mode takes over",
+			key:        "h",
+			cursor:     true,
+			wantAction: ActionLeft,
+			wantOk:     true,
+		},
+		{
+			name:       "bound",
+			action:     ActionStep,
+			keys:       []string{"x"},
+			key:        "x",
+			wantAction: ActionStep,
+			wantOk:     true,
+		},
+		{
+			name:   "replaced",
+			action: ActionStep,
+			keys:   []string{"x"},
+			key:    "Enter",
+			wantOk: false,
+		},
+		{
+			name:       "taken from another action",
+			action:     ActionPause,
+			keys:       []string{"c"},
+			key:        "c",
+			wantAction: ActionPause,
+			wantOk:     true,
+		},
+		{
+			name:       "kept by another mode",
+			action:     ActionInfo,
+			keys:       []string{"k"},
+			key:        "k",
+			cursor:     true,
+			wantAction: ActionUp,
+			wantOk:     true,
+		},
+		{
+			name:    "unknown",
+			action:  "jump",
+			keys:    []string{"j"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := newKeymap()
+			if tt.action != "" {
+				if err := k.bind(tt.action, tt.keys); (err != nil) != tt.wantErr {
+					t.Fatalf("keymap.bind() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if tt.wantErr {
+				return
+			}
+			find := k.Action
+			if tt.cursor {
+				find = k.Cursor
+			}
+			got, ok := find(tt.key)
+			if got != tt.wantAction || ok != tt.wantOk {
+				t.Errorf("keymap action of %q = %v, %v, want %v, %v", tt.key, got, ok, tt.wantAction, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_keymap_Help(t *testing.T) {
+	k := newKeymap()
+	if err := k.bind(ActionClear, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := "<Space>: pause, <Enter>: next"
+	if got := k.Help("<%s>: %s", ActionPause, ActionStep, ActionClear); got != want {
+		t.Errorf("keymap.Help() = %q, want %q", got, want)
+	}
+}
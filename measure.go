@@ -0,0 +1,89 @@
+package life
+
+import "math"
+
+// Population is the number of alive cells.
+func (g *game) Population() int {
+	return Population(g.s)
+}
+
+// Population of the pattern.
+func Population(s [][]int) int {
+	n := 0
+	for _, row := range s {
+		for _, v := range row {
+			if v > 0 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Center of the alive cells, averaged around the wrapping board so an
+// object across an edge keeps its place.
+func (g *game) Center() (float64, float64, bool) {
+	w, h := float64(g.Width()), float64(g.Height())
+	var xs, xc, ys, yc float64
+	n := 0
+	for y := range g.s {
+		for x := range g.s[y] {
+			if !g.s.alive(x, y) {
+				continue
+			}
+			xs += math.Sin(2 * math.Pi * float64(x) / w)
+			xc += math.Cos(2 * math.Pi * float64(x) / w)
+			ys += math.Sin(2 * math.Pi * float64(y) / h)
+			yc += math.Cos(2 * math.Pi * float64(y) / h)
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, 0, false
+	}
+	angle := func(s, c, size float64) float64 {
+		a := math.Atan2(s, c)
+		if a < 0 {
+			a += 2 * math.Pi
+		}
+		return a / (2 * math.Pi) * size
+	}
+	return angle(xs, xc, w), angle(ys, yc, h), true
+}
+
+// Centroid of the alive cells in the rectangle between corners x0 y0 and
+// x1 y1, which may lie off the board as it wraps around.
+func (g *game) Centroid(x0, y0, x1, y1 int) (float64, float64, bool) {
+	var sx, sy float64
+	n := 0
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			if g.s.alive(g.s.boundless(x, y)) {
+				sx += float64(x)
+				sy += float64(y)
+				n++
+			}
+		}
+	}
+	if n == 0 {
+		return 0, 0, false
+	}
+	return sx / float64(n), sy / float64(n), true
+}
+
+// Bounds of the alive cells in the rectangle between corners x0 y0 and
+// x1 y1, which may lie off the board as it wraps around. The ok result is
+// false when there are none.
+func (g *game) Bounds(x0, y0, x1, y1 int) (int, int, int, int, bool) {
+	if x1 < x0 || y1 < y0 {
+		return 0, 0, 0, 0, false
+	}
+	s := newState(x1-x0+1, y1-y0+1)
+	for y := range s {
+		for x := range s[y] {
+			s[y][x] = g.s.cycle(g.s.boundless(x0+x, y0+y))
+		}
+	}
+	bx0, by0, bx1, by1, ok := s.bounds(func(cycle int) bool { return cycle > 0 })
+	return x0 + bx0, y0 + by0, x0 + bx1, y0 + by1, ok
+}
@@ -2,11 +2,14 @@ package life
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"embed"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
@@ -105,12 +108,35 @@ func cells(r io.Reader) ([][]int, error) {
 	return state, nil
 }
 
+type point struct{ x, y int }
+
+// pointState places points into the smallest state that holds them all.
+func pointState(points []point) [][]int {
+	if len(points) == 0 {
+		return [][]int{}
+	}
+	minP, maxP := points[0], points[0]
+	for _, p := range points {
+		maxP = point{
+			x: max(maxP.x, p.x),
+			y: max(maxP.y, p.y),
+		}
+		minP = point{
+			x: min(minP.x, p.x),
+			y: min(minP.y, p.y),
+		}
+	}
+	state := make([][]int, maxP.y-minP.y+1)
+	for i := range state {
+		state[i] = make([]int, maxP.x-minP.x+1)
+	}
+	for _, p := range points {
+		state[p.y-minP.y][p.x-minP.x] = 1
+	}
+	return state
+}
+
 func life(r io.Reader) ([][]int, error) {
-	type point struct{ x, y int }
-	var (
-		maxP, minP point
-		once       bool
-	)
 	points := []point{}
 	scan := bufio.NewScanner(r)
 	for scan.Scan() {
@@ -123,42 +149,147 @@ func life(r io.Reader) ([][]int, error) {
 		if err != nil {
 			return nil, err
 		}
-		if !once {
-			maxP = point{
-				x: p.x,
-				y: p.y,
-			}
-			minP = point{
-				x: p.x,
-				y: p.y,
+		points = append(points, p)
+	}
+	if err := scan.Err(); err != nil {
+		return nil, fmt.Errorf("parse life: %w", err)
+	}
+	return pointState(points), nil
+}
+
+// macrocell parses two-state Golly macrocell files.
+func macrocell(r io.Reader) ([][]int, error) {
+	type node struct {
+		level    int
+		leaf     []point
+		children [4]int
+	}
+	nodes := []node{{}}
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		line := strings.TrimSpace(scan.Text())
+		if line == "" || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.ContainsAny(line, ".*$") {
+			n := node{level: 3}
+			var x, y int
+			for _, r := range line {
+				switch r {
+				case '.':
+					x++
+				case '*':
+					n.leaf = append(n.leaf, point{x: x, y: y})
+					x++
+				case '$':
+					x = 0
+					y++
+				}
 			}
-			once = true
+			nodes = append(nodes, n)
+			continue
 		}
-		maxP = point{
-			x: max(maxP.x, p.x),
-			y: max(maxP.y, p.y),
+		var n node
+		_, err := fmt.Sscanf(line, "%d %d %d %d %d",
+			&n.level, &n.children[0], &n.children[1], &n.children[2], &n.children[3])
+		if err != nil {
+			return nil, fmt.Errorf("parse macrocell: %w", err)
 		}
-		minP = point{
-			x: min(minP.x, p.x),
-			y: min(minP.y, p.y),
+		if n.level < 4 {
+			return nil, fmt.Errorf("parse macrocell: multi-state rules are unsupported")
 		}
-		points = append(points, p)
+		for _, c := range n.children {
+			if c < 0 || c >= len(nodes) {
+				return nil, fmt.Errorf("parse macrocell: node %d is undefined", c)
+			}
+		}
+		nodes = append(nodes, n)
 	}
 	if err := scan.Err(); err != nil {
-		return nil, fmt.Errorf("parse life: %w", err)
+		return nil, fmt.Errorf("parse macrocell: %w", err)
 	}
-	state := make([][]int, maxP.y-minP.y+1)
-	for i := range state {
-		state[i] = make([]int, maxP.x-minP.x+1)
+
+	points := []point{}
+	var walk func(i, x, y int)
+	walk = func(i, x, y int) {
+		if i == 0 {
+			return
+		}
+		n := nodes[i]
+		for _, p := range n.leaf {
+			points = append(points, point{x: x + p.x, y: y + p.y})
+		}
+		if n.level > 3 {
+			half := 1 << (n.level - 1)
+			walk(n.children[0], x, y)
+			walk(n.children[1], x+half, y)
+			walk(n.children[2], x, y+half)
+			walk(n.children[3], x+half, y+half)
+		}
 	}
-	for _, p := range points {
-		state[p.y-minP.y][p.x-minP.x] = 1
+	walk(len(nodes)-1, 0, 0)
+	return pointState(points), nil
+}
+
+// stdinName is the file name that reads the pattern from standard input.
+const stdinName = "-"
+
+const sniffSize = 4096
+
+// sniff guesses the pattern format by content and returns its extension.
+func sniff(r *bufio.Reader) (string, error) {
+	head, err := r.Peek(sniffSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return "", fmt.Errorf("sniff: %w", err)
 	}
-	return state, nil
+	switch {
+	case bytes.HasPrefix(head, []byte{0x1f, 0x8b}):
+		return ".gz", nil
+	case bytes.HasPrefix(head, []byte("\x89PNG")):
+		return ".png", nil
+	case bytes.HasPrefix(head, []byte("GIF8")):
+		return ".gif", nil
+	case bytes.HasPrefix(head, []byte{0xff, 0xd8, 0xff}):
+		return ".jpg", nil
+	}
+	for _, line := range strings.Split(string(head), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#Life"):
+			return ".life", nil
+		case strings.HasPrefix(line, "[M2]"):
+			return ".mc", nil
+		case strings.HasPrefix(line, "!"):
+			return ".cells", nil
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(strings.ReplaceAll(line, " ", ""), "x="):
+			return ".rle", nil
+		case strings.Trim(line, ".O") == "":
+			return ".cells", nil
+		case strings.Trim(line, "-0123456789 ") == "":
+			return ".life", nil
+		case strings.Trim(line, "0123456789bo$! ") == "":
+			// RLE without its header.
+			return ".rle", nil
+		default:
+			return "", fmt.Errorf("sniff: unknown pattern format")
+		}
+	}
+	return "", fmt.Errorf("sniff: unknown pattern format")
 }
 
-func parse(r io.Reader, name string) ([][]int, error) {
+func parse(r io.Reader, name string, o imageOptions) ([][]int, error) {
 	switch path.Ext(name) {
+	case ".gz":
+		z, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("parse gzip: %w", err)
+		}
+		defer z.Close()
+		return parse(z, strings.TrimSuffix(name, ".gz"), o)
 	case ".rle":
 		_, _, state, err := rle(r)
 		return state, err
@@ -166,18 +297,50 @@ func parse(r io.Reader, name string) ([][]int, error) {
 		return cells(r)
 	case ".life":
 		return life(r)
+	case ".mc":
+		return macrocell(r)
+	case ".png", ".gif", ".jpg", ".jpeg":
+		return bitmap(r, o)
 	default:
 		return nil, fmt.Errorf("parse: file %s is unsupported", name)
 	}
 }
 
-func parseFile(name string) ([][]int, error) {
+// patternExts are the extensions parse reads, besides ".gz".
+var patternExts = []string{".rle", ".cells", ".life", ".mc", ".png", ".gif", ".jpg", ".jpeg"}
+
+// supported reports whether parse reads the file by its extension.
+func supported(name string) bool {
+	return slices.Contains(patternExts, path.Ext(strings.TrimSuffix(name, ".gz")))
+}
+
+func parseSniff(r io.Reader, o imageOptions) ([][]int, error) {
+	b := bufio.NewReaderSize(r, sniffSize)
+	ext, err := sniff(b)
+	if err != nil {
+		return nil, err
+	}
+	if ext == ".gz" {
+		z, err := gzip.NewReader(b)
+		if err != nil {
+			return nil, fmt.Errorf("parse gzip: %w", err)
+		}
+		defer z.Close()
+		return parseSniff(z, o)
+	}
+	return parse(b, stdinName+ext, o)
+}
+
+func parseFile(name string, o imageOptions) ([][]int, error) {
+	if name == stdinName {
+		return parseSniff(os.Stdin, o)
+	}
 	f, err := os.Open(name)
 	if err != nil {
 		return nil, fmt.Errorf("open file: %w", err)
 	}
 	defer f.Close()
-	return parse(f, name)
+	return parse(f, name, o)
 }
 
 func parseFileEmbed(fs embed.FS, name string) ([][]int, error) {
@@ -186,5 +349,5 @@ func parseFileEmbed(fs embed.FS, name string) ([][]int, error) {
 		return nil, fmt.Errorf("open embed file: %w", err)
 	}
 	defer f.Close()
-	return parse(f, name)
+	return parse(f, name, defaultImage)
 }
@@ -1,6 +1,8 @@
 package life
 
 import (
+	"bytes"
+	"compress/gzip"
 	"io"
 	"reflect"
 	"strings"
@@ -360,3 +362,141 @@ func Test_life(t *testing.T) {
 		})
 	}
 }
+
+func Test_macrocell(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    [][]int
+		wantErr bool
+	}{
+		{
+			name: "glider",
+			args: args{
+				strings.NewReader(
+					strings.Join(
+						[]string{
+							"[M2] (golly 4.2)",
+							"#R B3/S23",
+							".*$..*$***$",
+							"4 0 0 0 1",
+						},
+						"\n",
+					),
+				),
+			},
+			want: [][]int{
+				{0, 1, 0},
+				{0, 0, 1},
+				{1, 1, 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "undefined node",
+			args: args{
+				strings.NewReader(
+					strings.Join(
+						[]string{
+							"[M2] (golly 4.2)",
+							"4 0 0 0 2",
+						},
+						"\n",
+					),
+				),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := macrocell(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("macrocell() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("macrocell() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseSniff(t *testing.T) {
+	gz := func(s string) io.Reader {
+		var b bytes.Buffer
+		z := gzip.NewWriter(&b)
+		z.Write([]byte(s))
+		z.Close()
+		return &b
+	}
+	stone := [][]int{
+		{1, 1},
+		{1, 1},
+	}
+	tests := []struct {
+		name    string
+		r       io.Reader
+		want    [][]int
+		wantErr bool
+	}{
+		{
+			name:    "rle",
+			r:       strings.NewReader("#N Block\nx = 2, y = 2, rule = B3/S23\n2o$2o!"),
+			want:    stone,
+			wantErr: false,
+		},
+		{
+			name:    "cells",
+			r:       strings.NewReader("!Name: Block\nOO\nOO"),
+			want:    stone,
+			wantErr: false,
+		},
+		{
+			name:    "life",
+			r:       strings.NewReader("#Life 1.06\n0 0\n1 0\n0 1\n1 1"),
+			want:    stone,
+			wantErr: false,
+		},
+		{
+			name:    "gzip rle",
+			r:       gz("x = 2, y = 2, rule = B3/S23\n2o$2o!"),
+			want:    stone,
+			wantErr: false,
+		},
+		{
+			name:    "rle without header",
+			r:       strings.NewReader("2o$2o!"),
+			want:    stone,
+			wantErr: false,
+		},
+		{
+			name:    "unknown",
+			r:       strings.NewReader("hello, world\nxyz"),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			r:       strings.NewReader(""),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSniff(tt.r, defaultImage)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSniff() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSniff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,11 @@ package life
 
 import (
 	"embed"
+	"fmt"
+	"io/fs"
 	"path"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -13,16 +17,20 @@ var embedFS embed.FS
 const embedDir = "presets"
 
 type preset struct {
-	name  string
-	state [][]int
+	name     string
+	category string
+	state    [][]int
 }
 
 type presets struct {
 	current int
 	store   []preset
+	errs    []error
+	// stamp is the current preset as rotated or mirrored.
+	stamp [][]int
 }
 
-func newPresets() (*presets, error) {
+func newPresets(dirs ...string) (*presets, error) {
 	p := &presets{
 		store: []preset{
 			{
@@ -66,6 +74,9 @@ func newPresets() (*presets, error) {
 	if err := p.load(); err != nil {
 		return nil, err
 	}
+	for _, d := range dirs {
+		p.loadDir(d)
+	}
 	p.sort()
 	return p, nil
 }
@@ -94,6 +105,70 @@ func (p *presets) load() error {
 	return nil
 }
 
+// loadDir adds the patterns under the directory, with the subdirectory
+// they are in as their category. The files that fail are kept as errors.
+func (p *presets) loadDir(dir string) {
+	filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			p.errs = append(p.errs, fmt.Errorf("preset %s: %w", name, err))
+			return nil
+		}
+		if d.IsDir() || !supported(name) {
+			return nil
+		}
+		s, err := parseFile(name, defaultImage)
+		if err != nil {
+			p.errs = append(p.errs, fmt.Errorf("preset %s: %w", name, err))
+			return nil
+		}
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			rel = filepath.Base(name)
+		}
+		rel = filepath.ToSlash(rel)
+		category := path.Dir(rel)
+		if category == "." {
+			category = ""
+		}
+		p.add(preset{
+			name:     patternName(path.Base(rel)),
+			category: category,
+			state:    s,
+		}, rel)
+		return nil
+	})
+}
+
+// patternName is the file name without its extensions.
+func patternName(file string) string {
+	file = strings.TrimSuffix(file, ".gz")
+	return strings.TrimSuffix(file, path.Ext(file))
+}
+
+// add the preset, named after its file rel when the name is taken, and
+// numbered when that is taken too.
+func (p *presets) add(s preset, rel string) {
+	taken := func(name string) bool {
+		return slices.ContainsFunc(p.store, func(o preset) bool {
+			return o.name == name
+		})
+	}
+	name := s.name
+	if taken(name) {
+		name = rel
+	}
+	for i := 2; taken(name); i++ {
+		name = fmt.Sprintf("%s (%d)", rel, i)
+	}
+	s.name = name
+	p.store = append(p.store, s)
+}
+
+// Errors of the user preset files that failed to load.
+func (p *presets) Errors() []error {
+	return p.errs
+}
+
 // Name preset.
 func (p *presets) Name() string {
 	return p.store[p.current].name
@@ -101,13 +176,141 @@ func (p *presets) Name() string {
 
 // Next preset.
 func (p *presets) Next() {
-	p.current++
-	if p.current > len(p.store)-1 {
-		p.current = 0
-	}
+	p.Select((p.current + 1) % len(p.store))
 }
 
 // State return current preset.
 func (p *presets) State() [][]int {
+	if p.stamp != nil {
+		return p.stamp
+	}
 	return p.store[p.current].state
 }
+
+// Prev preset.
+func (p *presets) Prev() {
+	p.Select((p.current + len(p.store) - 1) % len(p.store))
+}
+
+// Category of the current preset.
+func (p *presets) Category() string {
+	return p.CategoryAt(p.current)
+}
+
+// CategoryAt returns the category of the preset i, the presets out of a
+// category directory are classified on first use.
+func (p *presets) CategoryAt(i int) string {
+	s := &p.store[i]
+	if s.category == "" {
+		s.category = classify(s.state)
+	}
+	return s.category
+}
+
+// NextInCategory is the next preset of the same category.
+func (p *presets) NextInCategory() {
+	p.stepInCategory(1)
+}
+
+// PrevInCategory is the previous preset of the same category.
+func (p *presets) PrevInCategory() {
+	p.stepInCategory(-1)
+}
+
+func (p *presets) stepInCategory(d int) {
+	n := len(p.store)
+	for i := 1; i < n; i++ {
+		j := ((p.current+d*i)%n + n) % n
+		if p.CategoryAt(j) == p.Category() {
+			p.Select(j)
+			return
+		}
+	}
+}
+
+// NextCategory goes to the first preset of the next category by name.
+func (p *presets) NextCategory() {
+	next, first := -1, 0
+	for i := range p.store {
+		c := p.CategoryAt(i)
+		if c < p.CategoryAt(first) {
+			first = i
+		}
+		if c > p.Category() && (next < 0 || c < p.CategoryAt(next)) {
+			next = i
+		}
+	}
+	if next < 0 {
+		next = first
+	}
+	p.Select(next)
+}
+
+// Set the preset by name, reports whether it exists.
+func (p *presets) Set(name string) bool {
+	for i := range p.store {
+		if p.store[i].name == name {
+			p.Select(i)
+			return true
+		}
+	}
+	return false
+}
+
+// Len is the number of presets.
+func (p *presets) Len() int {
+	return len(p.store)
+}
+
+// Index of the current preset.
+func (p *presets) Index() int {
+	return p.current
+}
+
+// Select the preset i as the current one, unturned.
+func (p *presets) Select(i int) {
+	if i >= 0 && i < len(p.store) {
+		p.current = i
+		p.stamp = nil
+	}
+}
+
+// NameAt returns the name of the preset i.
+func (p *presets) NameAt(i int) string {
+	return p.store[i].name
+}
+
+// StateAt returns the state of the preset i.
+func (p *presets) StateAt(i int) [][]int {
+	return p.store[i].state
+}
+
+// Find the presets whose names or categories contain the query, regardless
+// of case.
+func (p *presets) Find(query string) []int {
+	query = strings.ToLower(query)
+	found := []int{}
+	for i, s := range p.store {
+		if strings.Contains(strings.ToLower(s.name), query) ||
+			strings.Contains(strings.ToLower(p.CategoryAt(i)), query) {
+			found = append(found, i)
+		}
+	}
+	return found
+}
+
+// previewMargin is the number of dead cells around a preview.
+const previewMargin = 8
+
+// Preview the preset i on a board of its own, to step apart from the game.
+func (p *presets) Preview(i int) *game {
+	w, h := Size(p.store[i].state)
+	g := newGame(w+2*previewMargin, h+2*previewMargin)
+	g.r = newRewind(0)
+	for y, row := range p.store[i].state {
+		for x, v := range row {
+			g.s.init(x+previewMargin, y+previewMargin, v > 0)
+		}
+	}
+	return g
+}
@@ -0,0 +1,144 @@
+package life
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_presets_Find(t *testing.T) {
+	p := &presets{
+		store: []preset{
+			{name: "glider"},
+			{name: "glider-gun"},
+			{name: "stone"},
+		},
+	}
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{
+			name:  "all",
+			query: "",
+			want:  []int{0, 1, 2},
+		},
+		{
+			name:  "ignore case",
+			query: "GLIDER",
+			want:  []int{0, 1},
+		},
+		{
+			name:  "none",
+			query: "ship",
+			want:  []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Find(tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("presets.Find() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_presets_loadDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"stone.cells":           "OO\nOO",
+		"glider.rle":            "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!",
+		"ships/glider.cells":    ".O\n..O\nOOO",
+		"broken/bad.life":       "#Life 1.06\nnot a point",
+		"notes/readme.txt":      "not a pattern",
+		"oscillators/p2/x.life": "#Life 1.06\n0 0\n1 0\n2 0",
+	}
+	for name, text := range files {
+		name = filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := &presets{store: []preset{{name: "stone"}}}
+	p.loadDir(dir)
+	p.sort()
+
+	got := map[string]string{}
+	for _, s := range p.store {
+		got[s.name] = s.category
+	}
+	want := map[string]string{
+		"stone":              "",
+		"stone.cells":        "",
+		"glider":             "",
+		"ships/glider.cells": "ships",
+		"x":                  "oscillators/p2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("presets.loadDir() = %v, want %v", got, want)
+	}
+	if errs := p.Errors(); len(errs) != 1 || !strings.Contains(errs[0].Error(), "bad.life") {
+		t.Errorf("presets.Errors() = %v, want the error of bad.life", errs)
+	}
+}
+
+func Test_presets_category(t *testing.T) {
+	p := &presets{
+		store: []preset{
+			{name: "blinker", category: CategoryOscillator},
+			{name: "block", category: CategoryStillLife},
+			{name: "galaxy", category: CategoryOscillator},
+			{name: "glider", category: CategorySpaceship},
+		},
+	}
+	tests := []struct {
+		name string
+		move func()
+		want string
+	}{
+		{
+			name: "next in category",
+			move: p.NextInCategory,
+			want: "galaxy",
+		},
+		{
+			name: "next in category wraps",
+			move: p.NextInCategory,
+			want: "blinker",
+		},
+		{
+			name: "previous in category wraps",
+			move: p.PrevInCategory,
+			want: "galaxy",
+		},
+		{
+			name: "next category",
+			move: p.NextCategory,
+			want: "glider",
+		},
+		{
+			name: "next category",
+			move: p.NextCategory,
+			want: "block",
+		},
+		{
+			name: "next category wraps",
+			move: p.NextCategory,
+			want: "blinker",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.move()
+			if got := p.Name(); got != tt.want {
+				t.Errorf("presets.Name() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
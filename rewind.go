@@ -0,0 +1,97 @@
+package life
+
+// rewindLimit is the default number of generations kept to step back.
+const rewindLimit = 100
+
+type run struct {
+	cycle, count int
+}
+
+// snapshot of a generation with the cells run-length encoded row by row.
+type snapshot struct {
+	w, h  int
+	cycle int
+	runs  []run
+}
+
+func compress(s state, cycle int) snapshot {
+	sn := snapshot{
+		w:     s.width(),
+		h:     s.height(),
+		cycle: cycle,
+	}
+	for y := range s {
+		for _, c := range s[y] {
+			if l := len(sn.runs) - 1; l >= 0 && sn.runs[l].cycle == c {
+				sn.runs[l].count++
+				continue
+			}
+			sn.runs = append(sn.runs, run{cycle: c, count: 1})
+		}
+	}
+	return sn
+}
+
+func (sn snapshot) state() state {
+	s := newState(sn.w, sn.h)
+	i := 0
+	for _, r := range sn.runs {
+		for j := 0; j < r.count; j++ {
+			s[i/sn.w][i%sn.w] = r.cycle
+			i++
+		}
+	}
+	return s
+}
+
+// rewind is a ring buffer of the last generations.
+type rewind struct {
+	buf  []snapshot
+	head int
+	len  int
+}
+
+func newRewind(n int) rewind {
+	return rewind{
+		buf: make([]snapshot, max(n, 0)),
+	}
+}
+
+func (r *rewind) push(sn snapshot) {
+	if len(r.buf) == 0 {
+		return
+	}
+	r.buf[r.head] = sn
+	r.head = (r.head + 1) % len(r.buf)
+	r.len = min(r.len+1, len(r.buf))
+}
+
+func (r *rewind) pop() (snapshot, bool) {
+	if r.len == 0 {
+		return snapshot{}, false
+	}
+	r.head = (r.head - 1 + len(r.buf)) % len(r.buf)
+	r.len--
+	sn := r.buf[r.head]
+	r.buf[r.head] = snapshot{}
+	return sn, true
+}
+
+func (r *rewind) reset() {
+	r.buf = make([]snapshot, len(r.buf))
+	r.head = 0
+	r.len = 0
+}
+
+// Back to the previous generation, reports whether one was kept.
+func (g *game) Back() bool {
+	sn, ok := g.r.pop()
+	if !ok {
+		return false
+	}
+	g.s = sn.state()
+	g.cycle = sn.cycle
+	// As with Step, the edits were made on another board.
+	g.h = history{}
+	return true
+}
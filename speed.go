@@ -0,0 +1,140 @@
+package life
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	minPeriod = 10 * time.Millisecond
+	maxPeriod = 2 * time.Second
+	// maxSkip is the most generations a refresh steps at full speed.
+	maxSkip = 1024
+)
+
+// speed of the run: the refresh period and, once it is the shortest, how
+// many generations a refresh steps.
+type speed struct {
+	period time.Duration
+	skip   int
+
+	count int
+	since time.Time
+	rate  float64
+}
+
+func newSpeed(period time.Duration) *speed {
+	return &speed{
+		period: min(max(period, minPeriod), maxPeriod),
+		skip:   1,
+		since:  time.Now(),
+	}
+}
+
+// Faster halves the period, or doubles the skipped generations.
+func (s *speed) Faster() {
+	if s.period > minPeriod {
+		s.period = max(s.period/2, minPeriod)
+		return
+	}
+	s.skip = min(s.skip*2, maxSkip)
+}
+
+// Slower undoes Faster.
+func (s *speed) Slower() {
+	if s.skip > 1 {
+		s.skip /= 2
+		return
+	}
+	s.period = min(s.period*2, maxPeriod)
+}
+
+// Period between the refreshes.
+func (s *speed) Period() time.Duration {
+	return s.period
+}
+
+// Skip is the number of generations a refresh steps.
+func (s *speed) Skip() int {
+	return s.skip
+}
+
+// Count n stepped generations for the rate.
+func (s *speed) Count(n int) {
+	s.count += n
+	if d := time.Since(s.since); d >= time.Second {
+		s.rate = float64(s.count) / d.Seconds()
+		s.count = 0
+		s.since = time.Now()
+	}
+}
+
+// Rate in generations per second.
+func (s *speed) Rate() float64 {
+	return s.rate
+}
+
+func (s *speed) String() string {
+	if s.skip > 1 {
+		return fmt.Sprintf("%v x%d", s.period, s.skip)
+	}
+	return s.period.String()
+}
+
+// StepTo steps towards the target generation until the deadline, back
+// through the kept generations for a target in the past. It returns the
+// number of generations gone and whether there are no more to go.
+func (g *game) StepTo(target int, deadline time.Time) (int, bool) {
+	n := 0
+	for g.cycle != target {
+		if time.Now().After(deadline) {
+			return n, false
+		}
+		if g.cycle > target {
+			if !g.Back() {
+				return n, true
+			}
+		} else {
+			g.Step()
+		}
+		n++
+	}
+	return n, true
+}
+
+// goal is a run to a generation, from the one it started at.
+type goal struct {
+	from, to int
+	ok       bool
+}
+
+// Set the goal to the generation, from the current one.
+func (gl *goal) Set(from, to int) {
+	*gl = goal{from: from, to: to, ok: true}
+}
+
+// Cancel the goal.
+func (gl *goal) Cancel() {
+	gl.ok = false
+}
+
+// Active reports whether there is a goal to run to.
+func (gl *goal) Active() bool {
+	return gl.ok
+}
+
+// Run the game towards the goal until the deadline. It returns the number
+// of generations gone and the progress message.
+func (gl *goal) Run(g *game, deadline time.Time) (int, string) {
+	n, done := g.StepTo(gl.to, deadline)
+	gl.ok = !done
+	cycle := g.Cycle()
+	switch {
+	case done && cycle != gl.to:
+		return n, fmt.Sprintf("Go to %d: stopped at %d, the generations before are not kept", gl.to, cycle)
+	case done:
+		return n, fmt.Sprintf("Go to %d: done", gl.to)
+	default:
+		return n, fmt.Sprintf("Go to %d: %d%%", gl.to, 100*abs(cycle-gl.from)/max(abs(gl.to-gl.from), 1))
+	}
+}
@@ -50,6 +50,24 @@ func (s state) alive(x, y int) bool {
 	return s.cycle(x, y) > 0
 }
 
+// bounds of the cells whose cycle matches, ok is false when there are none.
+func (s state) bounds(match func(cycle int) bool) (x0, y0, x1, y1 int, ok bool) {
+	for y := range s {
+		for x, cycle := range s[y] {
+			if !match(cycle) {
+				continue
+			}
+			if !ok {
+				x0, y0, x1, y1, ok = x, y, x, y, true
+				continue
+			}
+			x0, y0 = min(x0, x), min(y0, y)
+			x1, y1 = max(x1, x), max(y1, y)
+		}
+	}
+	return x0, y0, x1, y1, ok
+}
+
 func (s state) boundless(x, y int) (int, int) {
 	if !s.inside(x, y) {
 		x += s.width()
@@ -355,3 +355,45 @@ func Test_state_next(t *testing.T) {
 		})
 	}
 }
+
+func Test_state_bounds(t *testing.T) {
+	alive := func(cycle int) bool { return cycle > 0 }
+	tests := []struct {
+		name           string
+		s              state
+		x0, y0, x1, y1 int
+		wantOk         bool
+	}{
+		{
+			name: "glider",
+			s: [][]int{
+				{0, 0, 0, 0},
+				{0, 0, 1, 0},
+				{0, 0, 0, 1},
+				{0, 1, 1, 1},
+			},
+			x0:     1,
+			y0:     1,
+			x1:     3,
+			y1:     3,
+			wantOk: true,
+		},
+		{
+			name: "dead",
+			s: [][]int{
+				{0, -1},
+				{0, 0},
+			},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x0, y0, x1, y1, ok := tt.s.bounds(alive)
+			if ok != tt.wantOk || x0 != tt.x0 || y0 != tt.y0 || x1 != tt.x1 || y1 != tt.y1 {
+				t.Errorf("state.bounds() = %v %v %v %v %v, want %v %v %v %v %v",
+					x0, y0, x1, y1, ok, tt.x0, tt.y0, tt.x1, tt.y1, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package life
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// SVG options of the snapshot.
+type SVG struct {
+	// Cell size in pixels.
+	Cell int
+	// Trail draws the decaying dead cells.
+	Trail bool
+	// Crop to the bounding box of the drawn cells.
+	Crop bool
+}
+
+func hex(c RGB) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+}
+
+// WriteSVG snapshot of the current generation.
+func (a *App) WriteSVG(w io.Writer, o SVG) error {
+	if o.Cell < 1 {
+		return fmt.Errorf("export svg: cell size %d must be positive", o.Cell)
+	}
+	s := a.Game.s
+	drawn := func(cycle int) bool {
+		return cycle > 0 || o.Trail && cycle < 0 && a.Theme.Color(cycle) != a.Theme.Background()
+	}
+	x0, y0, x1, y1 := 0, 0, s.width()-1, s.height()-1
+	if o.Crop {
+		var ok bool
+		if x0, y0, x1, y1, ok = s.bounds(drawn); !ok {
+			x0, y0, x1, y1 = 0, 0, -1, -1
+		}
+	}
+	width := (x1 - x0 + 1) * o.Cell
+	height := (y1 - y0 + 1) * o.Cell
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		width, height, width, height)
+	fmt.Fprintf(b, "<rect width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", width, height, hex(a.Theme.Background()))
+	for y := y0; y <= y1; y++ {
+		// Neighbour cells of the same color share one rect.
+		for x := x0; x <= x1; {
+			cycle := s.cycle(x, y)
+			if !drawn(cycle) {
+				x++
+				continue
+			}
+			c := a.Theme.Color(cycle)
+			run := 1
+			for x+run <= x1 && drawn(s.cycle(x+run, y)) && a.Theme.Color(s.cycle(x+run, y)) == c {
+				run++
+			}
+			fmt.Fprintf(b, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n",
+				(x-x0)*o.Cell, (y-y0)*o.Cell, run*o.Cell, o.Cell, hex(c))
+			x += run
+		}
+	}
+	fmt.Fprintln(b, "</svg>")
+	if err := b.Flush(); err != nil {
+		return fmt.Errorf("export svg: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package life
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_App_WriteSVG(t *testing.T) {
+	// flat trails in the alive color, so that runs may mix them.
+	flat := theme{
+		name:       "flat",
+		background: NewRGB(255, 255, 255),
+		foreground: NewRGB(0, 0, 0),
+		alive:      []RGB{NewRGB(0, 0, 0)},
+		dead:       []RGB{NewRGB(0, 0, 0)},
+	}
+	tests := []struct {
+		name    string
+		state   [][]int
+		o       SVG
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "runs",
+			state: [][]int{
+				{1, 1, 1, 0},
+				{0, 1, 0, 1},
+			},
+			o: SVG{Cell: 2},
+			want: []string{
+				`<svg xmlns="http://www.w3.org/2000/svg" width="8" height="4" viewBox="0 0 8 4">`,
+				`<rect width="8" height="4" fill="#ffffff"/>`,
+				`<rect x="0" y="0" width="6" height="2" fill="#000000"/>`,
+				`<rect x="2" y="2" width="2" height="2" fill="#000000"/>`,
+				`<rect x="6" y="2" width="2" height="2" fill="#000000"/>`,
+			},
+		},
+		{
+			name: "crop",
+			state: [][]int{
+				{0, 0, 0, 0},
+				{0, 1, 1, 0},
+				{0, 0, 0, 0},
+			},
+			o: SVG{Cell: 1, Crop: true},
+			want: []string{
+				`<svg xmlns="http://www.w3.org/2000/svg" width="2" height="1" viewBox="0 0 2 1">`,
+				`<rect width="2" height="1" fill="#ffffff"/>`,
+				`<rect x="0" y="0" width="2" height="1" fill="#000000"/>`,
+			},
+		},
+		{
+			name: "no trail",
+			state: [][]int{
+				{-1, 1, 1, -1},
+			},
+			o: SVG{Cell: 1},
+			want: []string{
+				`<svg xmlns="http://www.w3.org/2000/svg" width="4" height="1" viewBox="0 0 4 1">`,
+				`<rect width="4" height="1" fill="#ffffff"/>`,
+				`<rect x="1" y="0" width="2" height="1" fill="#000000"/>`,
+			},
+		},
+		{
+			name: "trail",
+			state: [][]int{
+				{-1, 1, 1, -1},
+			},
+			o: SVG{Cell: 1, Trail: true},
+			want: []string{
+				`<svg xmlns="http://www.w3.org/2000/svg" width="4" height="1" viewBox="0 0 4 1">`,
+				`<rect width="4" height="1" fill="#ffffff"/>`,
+				`<rect x="0" y="0" width="4" height="1" fill="#000000"/>`,
+			},
+		},
+		{
+			name:    "no cell size",
+			state:   [][]int{{1}},
+			o:       SVG{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewApp(len(tt.state[0]), len(tt.state), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			a.Theme.add(flat)
+			a.Theme.Set(flat.name)
+			a.Game.s = state(tt.state)
+			var b bytes.Buffer
+			err = a.WriteSVG(&b, tt.o)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("App.WriteSVG() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			want := append(tt.want, "</svg>")
+			if got := strings.Split(strings.TrimSpace(b.String()), "\n"); !reflect.DeepEqual(got, want) {
+				t.Errorf("App.WriteSVG() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package term
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Cast records everything written to it as an asciinema v2 file.
+type Cast struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time
+}
+
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// NewCast writes the header for a terminal of width columns and height rows,
+// recorded under the TERM of the environment.
+func NewCast(w io.Writer, width, height int) (*Cast, error) {
+	start := time.Now()
+	header := castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: start.Unix(),
+	}
+	if t := os.Getenv("TERM"); t != "" {
+		header.Env = map[string]string{"TERM": t}
+	}
+	h, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("cast header: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", h); err != nil {
+		return nil, fmt.Errorf("cast header: %w", err)
+	}
+	return &Cast{
+		w:     w,
+		start: start,
+	}, nil
+}
+
+// Write p as an output event stamped with the time since the start.
+func (c *Cast) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, err := json.Marshal([]any{time.Since(c.start).Seconds(), "o", string(p)})
+	if err != nil {
+		return 0, fmt.Errorf("cast event: %w", err)
+	}
+	if _, err := fmt.Fprintf(c.w, "%s\n", e); err != nil {
+		return 0, fmt.Errorf("cast event: %w", err)
+	}
+	return len(p), nil
+}
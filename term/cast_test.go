@@ -0,0 +1,75 @@
+package term
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func Test_Cast(t *testing.T) {
+	tests := []struct {
+		name   string
+		term   string
+		writes []string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:   "events",
+			term:   "xterm",
+			writes: []string{"hello", "\x1b[2Jworld\n", `"quoted"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TERM", tt.term)
+			var b bytes.Buffer
+			c, err := NewCast(&b, 80, 24)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, w := range tt.writes {
+				if n, err := c.Write([]byte(w)); err != nil || n != len(w) {
+					t.Fatalf("Cast.Write() = %v, %v, want %v, nil", n, err, len(w))
+				}
+			}
+
+			scan := bufio.NewScanner(&b)
+			if !scan.Scan() {
+				t.Fatal("Cast has no header")
+			}
+			var h castHeader
+			if err := json.Unmarshal(scan.Bytes(), &h); err != nil {
+				t.Fatalf("header %q: %v", scan.Text(), err)
+			}
+			if h.Version != 2 || h.Width != 80 || h.Height != 24 || h.Timestamp == 0 {
+				t.Errorf("header = %+v, want version 2 of 80x24", h)
+			}
+			if got := h.Env["TERM"]; got != tt.term {
+				t.Errorf("header TERM = %q, want %q", got, tt.term)
+			}
+
+			i := 0
+			last := 0.0
+			for ; scan.Scan(); i++ {
+				var e []any
+				if err := json.Unmarshal(scan.Bytes(), &e); err != nil {
+					t.Fatalf("event %q: %v", scan.Text(), err)
+				}
+				if i >= len(tt.writes) {
+					continue
+				}
+				at, ok := e[0].(float64)
+				if len(e) != 3 || !ok || at < last || e[1] != "o" || e[2] != tt.writes[i] {
+					t.Errorf("event %v = %q, want [t, \"o\", %q]", i, scan.Text(), tt.writes[i])
+				}
+				last = at
+			}
+			if i != len(tt.writes) {
+				t.Errorf("events = %v, want %v", i, len(tt.writes))
+			}
+		})
+	}
+}
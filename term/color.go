@@ -0,0 +1,162 @@
+package term
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Mode of the colors a terminal shows.
+type Mode int
+
+const (
+	// Auto detects the mode.
+	Auto Mode = iota
+	TrueColor
+	Color256
+	Color16
+	Mono
+)
+
+func (m Mode) String() string {
+	switch m {
+	case TrueColor:
+		return "true"
+	case Color256:
+		return "256"
+	case Color16:
+		return "16"
+	case Mono:
+		return "mono"
+	default:
+		return "auto"
+	}
+}
+
+// ParseMode reads the mode as its String.
+func ParseMode(s string) (Mode, error) {
+	for m := Auto; m <= Mono; m++ {
+		if s == m.String() {
+			return m, nil
+		}
+	}
+	return Auto, fmt.Errorf("color mode %q is not auto, true, 256, 16 or mono", s)
+}
+
+// Detect the mode from the NO_COLOR, COLORTERM and TERM variables.
+func Detect() Mode {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return Mono
+	}
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	t := os.Getenv("TERM")
+	switch {
+	case t == "" || t == "dumb":
+		return Mono
+	case strings.Contains(t, "direct") || strings.Contains(t, "truecolor"):
+		return TrueColor
+	case strings.Contains(t, "256"):
+		return Color256
+	default:
+		return Color16
+	}
+}
+
+// ModeOf the number of colors a terminal reports.
+func ModeOf(colors int) Mode {
+	switch {
+	case colors >= 1<<24:
+		return TrueColor
+	case colors >= 256:
+		return Color256
+	case colors >= 8:
+		return Color16
+	default:
+		return Mono
+	}
+}
+
+// ansi16 are the colors of the 16 color palette as xterm shows them.
+var ansi16 = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cube are the levels of the 6x6x6 color cube of the 256 color palette.
+var cube = [6]int{0, 95, 135, 175, 215, 255}
+
+func distance(r, g, b int, c [3]int) int {
+	dr, dg, db := r-c[0], g-c[1], b-c[2]
+	return dr*dr + dg*dg + db*db
+}
+
+// Index16 of the nearest color in the 16 color palette.
+func Index16(c rgb) int {
+	r, g, b := c.Color()
+	best := 0
+	for i, p := range ansi16 {
+		if distance(int(r), int(g), int(b), p) < distance(int(r), int(g), int(b), ansi16[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// Index256 of the nearest color in the cube or the gray ramp of the 256
+// color palette.
+func Index256(c rgb) int {
+	r, g, b := c.Color()
+	level := func(v uint8) int {
+		// The levels rise, so the nearest is the last one past the midpoint.
+		best := 0
+		for i := 1; i < len(cube); i++ {
+			if int(v) > (cube[i-1]+cube[i])/2 {
+				best = i
+			}
+		}
+		return best
+	}
+	lr, lg, lb := level(r), level(g), level(b)
+	color := [3]int{cube[lr], cube[lg], cube[lb]}
+
+	gray := min(max((int(r)+int(g)+int(b))/3-8+5, 0)/10, 23)
+	v := 8 + gray*10
+	if distance(int(r), int(g), int(b), [3]int{v, v, v}) < distance(int(r), int(g), int(b), color) {
+		return 232 + gray
+	}
+	return 16 + 36*lr + 6*lg + lb
+}
+
+// escColor is the sequence that colors the background or the foreground.
+func (m Mode) escColor(background bool, c rgb) string {
+	switch m {
+	case Mono:
+		return ""
+	case Color16:
+		i := Index16(c)
+		base := 30
+		if background {
+			base = 40
+		}
+		if i >= 8 {
+			base += 60
+			i -= 8
+		}
+		return fmt.Sprintf("\x1b[%dm", base+i)
+	case Color256:
+		if background {
+			return fmt.Sprintf(escBackground256, Index256(c))
+		}
+		return fmt.Sprintf(escForeground256, Index256(c))
+	default:
+		if background {
+			return rgbTo(escBackgroundRGB, c)
+		}
+		return rgbTo(escForegroundRGB, c)
+	}
+}
@@ -0,0 +1,59 @@
+package term
+
+import "testing"
+
+type color struct{ r, g, b uint8 }
+
+func (c color) Color() (uint8, uint8, uint8) {
+	return c.r, c.g, c.b
+}
+
+func Test_Index(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       color
+		want16  int
+		want256 int
+	}{
+		{
+			name:    "black",
+			c:       color{0, 0, 0},
+			want16:  0,
+			want256: 16,
+		},
+		{
+			name:    "white",
+			c:       color{255, 255, 255},
+			want16:  15,
+			want256: 231,
+		},
+		{
+			name:    "red",
+			c:       color{255, 0, 0},
+			want16:  9,
+			want256: 196,
+		},
+		{
+			name:    "gray",
+			c:       color{128, 128, 128},
+			want16:  8,
+			want256: 244,
+		},
+		{
+			name:    "dark blue",
+			c:       color{0, 0, 130},
+			want16:  4,
+			want256: 18,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Index16(tt.c); got != tt.want16 {
+				t.Errorf("Index16() = %v, want %v", got, tt.want16)
+			}
+			if got := Index256(tt.c); got != tt.want256 {
+				t.Errorf("Index256() = %v, want %v", got, tt.want256)
+			}
+		})
+	}
+}
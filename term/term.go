@@ -1,6 +1,7 @@
 package term
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io"
 	"strings"
@@ -12,7 +13,10 @@ const (
 	escStartOfLine   = "\x1b[1G"
 	escBackgroundRGB = "\x1b[48;2;%d;%d;%dm"
 	escForegroundRGB = "\x1b[38;2;%d;%d;%dm"
+	escBackground256 = "\x1b[48;5;%dm"
+	escForeground256 = "\x1b[38;5;%dm"
 	escReset         = "\x1b[0m"
+	escClipboard     = "\x1b]52;c;%s\a"
 )
 
 type rgb interface {
@@ -32,43 +36,56 @@ type term struct {
 	w     io.Writer
 	s     strings.Builder
 	lines int
+	mode  Mode
 }
 
-func New(w io.Writer) Term {
+// New terminal that writes colors in the mode, Auto detects it.
+func New(w io.Writer, m Mode) Term {
+	if m == Auto {
+		m = Detect()
+	}
 	return &term{
 		w:     w,
 		s:     strings.Builder{},
 		lines: 0,
+		mode:  m,
 	}
 }
 
-func (t *term) clear() {
+func (t *term) clear(w io.Writer) {
 	if t.lines == 0 {
-		fmt.Fprint(t.w, escStartOfLine)
-		fmt.Fprint(t.w, escClearLines)
+		fmt.Fprint(w, escStartOfLine)
+		fmt.Fprint(w, escClearLines)
 		return
 	}
 	for i := 0; i < t.lines; i++ {
-		fmt.Fprint(t.w, escUp)
-		fmt.Fprint(t.w, escClearLines)
+		fmt.Fprint(w, escUp)
+		fmt.Fprint(w, escClearLines)
 	}
 }
 
 func (t *term) Print() {
-	t.clear()
+	// A frame goes in one write, so recorders see it whole.
+	var frame strings.Builder
+	t.clear(&frame)
 	content := t.s.String()
 	t.s.Reset()
-	fmt.Fprint(t.w, content)
+	frame.WriteString(content)
+	fmt.Fprint(t.w, frame.String())
 	t.lines = strings.Count(content, "\n")
 }
 
 func (t *term) Write(background, foreground rgb, s string) {
 	var b, f string
 	if background != nil {
-		b = rgbTo(escBackgroundRGB, background)
+		b = t.mode.escColor(true, background)
 	}
 	if foreground != nil {
-		f = rgbTo(escForegroundRGB, foreground)
+		f = t.mode.escColor(false, foreground)
+	}
+	if t.mode == Mono {
+		fmt.Fprint(&t.s, s)
+		return
 	}
 	fmt.Fprint(&t.s, b, f, s, escReset)
 }
@@ -81,3 +98,9 @@ func rgbTo(format string, c rgb) string {
 	r, g, b := c.Color()
 	return fmt.Sprintf(format, r, g, b)
 }
+
+// Clipboard sets the system clipboard with the OSC 52 sequence, terminals
+// without its support ignore it.
+func Clipboard(w io.Writer, text string) {
+	fmt.Fprintf(w, escClipboard, base64.StdEncoding.EncodeToString([]byte(text)))
+}
@@ -1,6 +1,9 @@
 package life
 
-import "sort"
+import (
+	"image/color"
+	"sort"
+)
 
 type RGB struct {
 	r uint8
@@ -20,17 +23,33 @@ func (c RGB) Color() (uint8, uint8, uint8) {
 	return c.r, c.g, c.b
 }
 
+// RGBA returns the opaque image color.
+func (c RGB) RGBA() color.RGBA {
+	return color.RGBA{R: c.r, G: c.g, B: c.b, A: 255}
+}
+
+// Mix colors a and b, t is the share of b.
+func Mix(a, b RGB, t float64) RGB {
+	m := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5)
+	}
+	return NewRGB(m(a.r, b.r), m(a.g, b.g), m(a.b, b.b))
+}
+
 type theme struct {
 	name       string
 	background RGB
 	foreground RGB
 	alive      []RGB
 	dead       []RGB
+	// cyclic alive colors repeat instead of keeping the last one.
+	cyclic bool
 }
 
 type themes struct {
 	current int
 	store   []theme
+	errs    []error
 }
 
 func newThemes() *themes {
@@ -183,12 +202,54 @@ func newThemes() *themes {
 					NewRGB(0, 10, 0),
 				},
 			},
+			// The themes below keep to the WCAG contrast in hues color blind eyes tell apart.
+			{
+				name:       "highContrastDark",
+				background: NewRGB(0, 0, 0),
+				foreground: NewRGB(255, 255, 255),
+				alive:      gradient(NewRGB(255, 255, 255), NewRGB(255, 220, 0), 6, SpaceOKLab),
+				dead:       gradient(NewRGB(90, 90, 170), NewRGB(15, 15, 30), 6, SpaceOKLab),
+			},
+			{
+				name:       "highContrastLight",
+				background: NewRGB(255, 255, 255),
+				foreground: NewRGB(0, 0, 0),
+				alive:      gradient(NewRGB(0, 0, 0), NewRGB(0, 40, 120), 6, SpaceOKLab),
+				dead:       gradient(NewRGB(130, 130, 150), NewRGB(240, 240, 245), 6, SpaceOKLab),
+			},
+			{
+				// Blue and orange for deuteranopia and protanopia.
+				name:       "blueAndOrange",
+				background: NewRGB(0, 0, 0),
+				foreground: NewRGB(240, 228, 66),
+				alive:      gradient(NewRGB(240, 228, 66), NewRGB(230, 159, 0), 8, SpaceOKLab),
+				dead:       gradient(NewRGB(0, 92, 160), NewRGB(0, 20, 35), 6, SpaceOKLab),
+			},
+			{
+				// Magenta and teal for tritanopia.
+				name:       "magentaAndTeal",
+				background: NewRGB(0, 0, 0),
+				foreground: NewRGB(255, 255, 255),
+				alive:      gradient(NewRGB(255, 225, 240), NewRGB(255, 125, 230), 8, SpaceOKLab),
+				dead:       gradient(NewRGB(0, 102, 102), NewRGB(0, 25, 25), 6, SpaceOKLab),
+			},
 		},
 	}
 	t.sort()
 	return t
 }
 
+// luminance of the color, relative as WCAG defines it.
+func (c RGB) luminance() float64 {
+	return 0.2126*linear(c.r) + 0.7152*linear(c.g) + 0.0722*linear(c.b)
+}
+
+// contrast ratio of the colors as WCAG defines it, from 1 to 21.
+func contrast(a, b RGB) float64 {
+	la, lb := a.luminance(), b.luminance()
+	return (max(la, lb) + 0.05) / (min(la, lb) + 0.05)
+}
+
 func (t *themes) theme() theme {
 	return t.store[t.current]
 }
@@ -202,9 +263,15 @@ func (t *themes) sort() {
 func (t *themes) alive(cycle int) RGB {
 	c := cycle - 1
 	l := len(t.theme().alive)
+	if l == 0 {
+		return t.theme().foreground
+	}
 	if c < l {
 		return t.theme().alive[c]
 	}
+	if t.theme().cyclic {
+		return t.theme().alive[c%l]
+	}
 	return t.theme().alive[l-1]
 }
 
@@ -239,6 +306,30 @@ func (t *themes) Color(cycle int) RGB {
 	}
 }
 
+// shades of the dead cells from the youngest, for Shade.
+var shades = []rune{'▓', '▒', '░'}
+
+// Shade is the glyph of the cell for terminals without colors: the alive
+// cells are solid and the dead ones fade out as their colors do.
+func (t *themes) Shade(cycle int) rune {
+	switch {
+	case cycle > 0:
+		return '█'
+	case cycle < 0:
+		c := cycle*-1 - 1
+		l := len(t.theme().dead)
+		if c < l {
+			return shades[c*len(shades)/l]
+		}
+	}
+	return ' '
+}
+
+// Grid color between the cells.
+func (t *themes) Grid() RGB {
+	return Mix(t.theme().background, t.theme().foreground, 0.2)
+}
+
 // Name theme.
 func (t *themes) Name() string {
 	return t.theme().name
@@ -251,3 +342,14 @@ func (t *themes) Next() {
 		t.current = 0
 	}
 }
+
+// Set the theme by name, reports whether it exists.
+func (t *themes) Set(name string) bool {
+	for i := range t.store {
+		if t.store[i].name == name {
+			t.current = i
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package life
+
+import "testing"
+
+func Test_themes_contrast(t *testing.T) {
+	const (
+		// minText is the WCAG AA contrast of normal text.
+		minText = 4.5
+		// minShape is the WCAG AA contrast of graphics.
+		minShape = 3
+	)
+	ts := newThemes()
+	for _, name := range []string{"highContrastDark", "highContrastLight", "blueAndOrange", "magentaAndTeal"} {
+		t.Run(name, func(t *testing.T) {
+			if !ts.Set(name) {
+				t.Fatalf("theme %s is missing", name)
+			}
+			th := ts.theme()
+			if c := contrast(th.foreground, th.background); c < minText {
+				t.Errorf("foreground to background contrast = %.2f, want at least %v", c, minText)
+			}
+			// The older trail fades into the background on purpose.
+			if c := contrast(th.dead[0], th.background); c < minShape {
+				t.Errorf("dead[0] to background contrast = %.2f, want at least %v", c, minShape)
+			}
+			for i, alive := range th.alive {
+				if c := contrast(alive, th.background); c < minText {
+					t.Errorf("alive[%d] to background contrast = %.2f, want at least %v", i, c, minText)
+				}
+				for j, dead := range th.dead {
+					if c := contrast(alive, dead); c < minShape {
+						t.Errorf("alive[%d] to dead[%d] contrast = %.2f, want at least %v", i, j, c, minShape)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package life
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// themeFile is a theme as written in a JSON file, colors in hex. Cyclic
+// alive colors repeat for the old cells rather than keep the last one.
+type themeFile struct {
+	Name       string      `json:"name"`
+	Background string      `json:"background"`
+	Foreground string      `json:"foreground"`
+	Alive      paletteFile `json:"alive"`
+	Dead       paletteFile `json:"dead"`
+	Cyclic     bool        `json:"cyclic"`
+}
+
+// paletteFile is a list of hex colors, or a gradient object.
+type paletteFile struct {
+	list     []string
+	gradient *gradientFile
+}
+
+func (p *paletteFile) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &p.list); err == nil {
+		return nil
+	}
+	p.gradient = &gradientFile{}
+	return json.Unmarshal(b, p.gradient)
+}
+
+func (p paletteFile) empty() bool {
+	return len(p.list) == 0 && p.gradient == nil
+}
+
+func (p paletteFile) colors(name string) ([]RGB, error) {
+	if p.gradient == nil {
+		return parseHexList(name, p.list)
+	}
+	c, err := p.gradient.colors()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return c, nil
+}
+
+// themeDir holds the user themes in the XDG config directory.
+func themeDir() string {
+	d := xdgDir("XDG_CONFIG_HOME", ".config")
+	if d == "" {
+		return ""
+	}
+	return filepath.Join(d, appName, "themes")
+}
+
+// parseHex reads a color as #rrggbb or #rgb, the # being optional.
+func parseHex(s string) (RGB, error) {
+	h := strings.TrimPrefix(s, "#")
+	if len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	if len(h) != 6 {
+		return RGB{}, fmt.Errorf("color %q is not #rrggbb", s)
+	}
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return RGB{}, fmt.Errorf("color %q is not #rrggbb", s)
+	}
+	return NewRGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+}
+
+func parseHexList(name string, list []string) ([]RGB, error) {
+	c := make([]RGB, 0, len(list))
+	for i, s := range list {
+		rgb, err := parseHex(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", name, i, err)
+		}
+		c = append(c, rgb)
+	}
+	return c, nil
+}
+
+// theme validates the file and makes the theme of it.
+func (f themeFile) theme() (theme, error) {
+	if f.Name == "" {
+		return theme{}, fmt.Errorf("name is empty")
+	}
+	if f.Alive.empty() {
+		return theme{}, fmt.Errorf("alive has no colors")
+	}
+	t := theme{name: f.Name, cyclic: f.Cyclic}
+	var err error
+	if t.background, err = parseHex(f.Background); err != nil {
+		return theme{}, fmt.Errorf("background: %w", err)
+	}
+	if t.foreground, err = parseHex(f.Foreground); err != nil {
+		return theme{}, fmt.Errorf("foreground: %w", err)
+	}
+	if t.alive, err = f.Alive.colors("alive"); err != nil {
+		return theme{}, err
+	}
+	if t.dead, err = f.Dead.colors("dead"); err != nil {
+		return theme{}, err
+	}
+	if t.cyclic && f.Alive.gradient != nil {
+		// The gradient goes back to its start to cycle without a jump.
+		for i := len(t.alive) - 2; i > 0; i-- {
+			t.alive = append(t.alive, t.alive[i])
+		}
+	}
+	return t, nil
+}
+
+// loadDir adds the themes of the JSON files in the directory, replacing the
+// ones of the same name. The files that fail are kept as errors.
+func (t *themes) loadDir(dir string) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.errs = append(t.errs, fmt.Errorf("themes: %w", err))
+		return
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		name := filepath.Join(dir, f.Name())
+		th, err := loadTheme(name)
+		if err != nil {
+			t.errs = append(t.errs, fmt.Errorf("theme %s: %w", name, err))
+			continue
+		}
+		t.add(th)
+	}
+	t.sort()
+}
+
+func loadTheme(name string) (theme, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return theme{}, err
+	}
+	var f themeFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return theme{}, err
+	}
+	return f.theme()
+}
+
+// add the theme, or replace the one of the same name.
+func (t *themes) add(th theme) {
+	for i := range t.store {
+		if t.store[i].name == th.name {
+			t.store[i] = th
+			return
+		}
+	}
+	t.store = append(t.store, th)
+}
+
+// Errors of the user theme files that failed to load.
+func (t *themes) Errors() []error {
+	return t.errs
+}
@@ -0,0 +1,101 @@
+package life
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_themeFile_theme(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       themeFile
+		want    theme
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			f: themeFile{
+				Name:       "paper",
+				Background: "#ffffff",
+				Foreground: "000",
+				Alive:      paletteFile{list: []string{"#102030"}},
+				Dead:       paletteFile{list: []string{"#eeeeee"}},
+			},
+			want: theme{
+				name:       "paper",
+				background: NewRGB(255, 255, 255),
+				foreground: NewRGB(0, 0, 0),
+				alive:      []RGB{NewRGB(16, 32, 48)},
+				dead:       []RGB{NewRGB(238, 238, 238)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "no alive colors",
+			f: themeFile{
+				Name:       "empty",
+				Background: "#ffffff",
+				Foreground: "#000000",
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad color",
+			f: themeFile{
+				Name:       "bad",
+				Background: "#ffffff",
+				Foreground: "#000000",
+				Alive:      paletteFile{list: []string{"#12345g"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no name",
+			f: themeFile{
+				Background: "#ffffff",
+				Foreground: "#000000",
+				Alive:      paletteFile{list: []string{"#000000"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.theme()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("themeFile.theme() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("themeFile.theme() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_themeFile_cyclic(t *testing.T) {
+	var f themeFile
+	err := json.Unmarshal([]byte(`{
+		"name": "loop",
+		"background": "#000",
+		"foreground": "#fff",
+		"alive": {"from": "#000000", "to": "#ffffff", "length": 3, "space": "rgb"},
+		"cyclic": true
+	}`), &f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	th, err := f.theme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := &themes{store: []theme{th}}
+	gray := NewRGB(128, 128, 128)
+	want := []RGB{NewRGB(0, 0, 0), gray, NewRGB(255, 255, 255), gray, NewRGB(0, 0, 0)}
+	for i, w := range want {
+		if got := ts.Color(i + 1); got != w {
+			t.Errorf("themes.Color(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package life
+
+// Size of a possibly ragged pattern.
+func Size(s [][]int) (int, int) {
+	w := 0
+	for _, row := range s {
+		w = max(w, len(row))
+	}
+	return w, len(s)
+}
+
+// rotate the pattern clockwise by 90 degrees.
+func rotate(s [][]int) [][]int {
+	w, h := Size(s)
+	r := newState(h, w)
+	for y := range s {
+		for x, v := range s[y] {
+			r[x][h-1-y] = v
+		}
+	}
+	return r
+}
+
+// flipH mirrors the pattern left to right.
+func flipH(s [][]int) [][]int {
+	w, h := Size(s)
+	r := newState(w, h)
+	for y := range s {
+		for x, v := range s[y] {
+			r[y][w-1-x] = v
+		}
+	}
+	return r
+}
+
+// flipV mirrors the pattern top to bottom.
+func flipV(s [][]int) [][]int {
+	w, h := Size(s)
+	r := newState(w, h)
+	for y := range s {
+		for x, v := range s[y] {
+			r[h-1-y][x] = v
+		}
+	}
+	return r
+}
+
+// Rotate the current preset clockwise.
+func (p *presets) Rotate() {
+	p.stamp = rotate(p.State())
+}
+
+// FlipH mirrors the current preset left to right.
+func (p *presets) FlipH() {
+	p.stamp = flipH(p.State())
+}
+
+// FlipV mirrors the current preset top to bottom.
+func (p *presets) FlipV() {
+	p.stamp = flipV(p.State())
+}
+
+// Rotate the clipboard clockwise.
+func (c *clipboard) Rotate() {
+	c.state = rotate(c.state)
+}
+
+// FlipH mirrors the clipboard left to right.
+func (c *clipboard) FlipH() {
+	c.state = flipH(c.state)
+}
+
+// FlipV mirrors the clipboard top to bottom.
+func (c *clipboard) FlipV() {
+	c.state = flipV(c.state)
+}
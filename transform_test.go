@@ -0,0 +1,93 @@
+package life
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_transform(t *testing.T) {
+	glider := [][]int{
+		{0, 1, 0},
+		{0, 0, 1},
+		{1, 1, 1},
+	}
+	tests := []struct {
+		name string
+		f    func([][]int) [][]int
+		s    [][]int
+		want [][]int
+	}{
+		{
+			name: "rotate",
+			f:    rotate,
+			s:    glider,
+			want: [][]int{
+				{1, 0, 0},
+				{1, 0, 1},
+				{1, 1, 0},
+			},
+		},
+		{
+			name: "rotate ragged",
+			f:    rotate,
+			s: [][]int{
+				{1, 1, 1},
+				{1},
+			},
+			want: [][]int{
+				{1, 1},
+				{0, 1},
+				{0, 1},
+			},
+		},
+		{
+			name: "flip horizontal",
+			f:    flipH,
+			s:    glider,
+			want: [][]int{
+				{0, 1, 0},
+				{1, 0, 0},
+				{1, 1, 1},
+			},
+		},
+		{
+			name: "flip vertical",
+			f:    flipV,
+			s:    glider,
+			want: [][]int{
+				{1, 1, 1},
+				{0, 0, 1},
+				{0, 1, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_presets_Rotate(t *testing.T) {
+	line := [][]int{{1, 1}}
+	p := &presets{
+		store: []preset{
+			{name: "line", state: line},
+			{name: "block", state: [][]int{{1, 1}, {1, 1}}},
+		},
+	}
+	p.Rotate()
+	if got, want := p.State(), [][]int{{1}, {1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("presets.State() = %v, want %v", got, want)
+	}
+	if got := p.StateAt(0); !reflect.DeepEqual(got, line) {
+		t.Errorf("presets.StateAt() = %v, want the store left as %v", got, line)
+	}
+	p.Next()
+	p.Prev()
+	if got := p.State(); !reflect.DeepEqual(got, line) {
+		t.Errorf("presets.State() = %v, want %v after switching", got, line)
+	}
+}